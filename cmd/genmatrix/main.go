@@ -0,0 +1,167 @@
+// genmatrix generates the composite functions of the `slices` package, that
+// combine an iteration method (`Map`, `FlatMap`, `FilterMap`) with a traversal
+// mode (`Cons`, `Slice`, ...), along with their tests. The list of traversal
+// modes is defined in `modes.go`.
+//
+// Usage, from the `slices` package directory:
+//
+//	go run ../../cmd/genmatrix -o mappers_gen.go -test mappers_gen_test.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	var output = flag.String("o", "mappers_gen.go", "output file for generated functions")
+	var testOutput = flag.String("test", "mappers_gen_test.go", "output file for generated tests")
+	flag.Parse()
+
+	if err := generate(*output, sourceTemplate); err != nil {
+		fmt.Fprintf(os.Stderr, "genmatrix: %v\n", err)
+		os.Exit(1)
+	}
+	if err := generate(*testOutput, testTemplate); err != nil {
+		fmt.Fprintf(os.Stderr, "genmatrix: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(filename string, tmpl *template.Template) error {
+	src, err := render(tmpl)
+	if err != nil {
+		return fmt.Errorf("generating %v: %w", filename, err)
+	}
+	return os.WriteFile(filename, src, 0644)
+}
+
+func render(tmpl *template.Template) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, modes); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// comment formats `text` as a doc comment wrapped at 80 columns.
+func comment(text ...string) string {
+	var lines []string
+	var line = "//"
+	for _, w := range strings.Fields(strings.Join(text, "")) {
+		if len(line)+1+len(w) > 80 {
+			lines = append(lines, line)
+			line = "//"
+		}
+		line += " " + w
+	}
+	lines = append(lines, line)
+	return strings.Join(lines, "\n")
+}
+
+var funcs = template.FuncMap{"comment": comment}
+
+var sourceTemplate = template.Must(template.New("source").Funcs(funcs).Parse(`// Code generated by genmatrix; DO NOT EDIT.
+
+package slices
+{{range .}}
+// ---------------------------------------------------------------------------
+// {{.Name}}
+
+{{comment "Map" .Name " " .Doc " and collects one result per invocation."}}
+func Map{{.Name}}[T any, {{.TypeParams}}](v {{.Input}}, {{with .Params}}{{.}}, {{end}}f func({{.Callback}}) {{.Result}}) []{{.Result}} {
+	var r = make([]{{.Result}}, 0, len(v))
+	Each{{.Name}}(v, {{with .Args}}{{.}}, {{end}}func({{.Callback}}) {
+		r = append(r, f({{.CallbackArgs}}))
+	})
+	return r
+}
+
+{{comment "FlatMap" .Name " " .Doc " and collects zero, one or more results per invocation."}}
+func FlatMap{{.Name}}[T any, {{.TypeParams}}](v {{.Input}}, {{with .Params}}{{.}}, {{end}}f func({{.Callback}}) []{{.Result}}) []{{.Result}} {
+	var r []{{.Result}}
+	Each{{.Name}}(v, {{with .Args}}{{.}}, {{end}}func({{.Callback}}) {
+		r = append(r, f({{.CallbackArgs}})...)
+	})
+	return r
+}
+
+{{comment "FilterMap" .Name " " .Doc " and collects zero or one result per invocation."}}
+func FilterMap{{.Name}}[T any, {{.TypeParams}}](v {{.Input}}, {{with .Params}}{{.}}, {{end}}f func({{.Callback}}) ({{.Result}}, bool)) []{{.Result}} {
+	var r []{{.Result}}
+	Each{{.Name}}(v, {{with .Args}}{{.}}, {{end}}func({{.Callback}}) {
+		if aa, keep := f({{.CallbackArgs}}); keep {
+			r = append(r, aa)
+		}
+	})
+	return r
+}
+
+// {{.Name}}
+// ---------------------------------------------------------------------------
+{{end}}`))
+
+var testTemplate = template.Must(template.New("test").Parse(`// Code generated by genmatrix; DO NOT EDIT.
+
+package slices_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// everyOther returns the elements of v with an even index, matching the
+// results kept by the FilterMap callbacks below.
+func everyOther(v [][]int) [][]int {
+	var r [][]int
+	for i, a := range v {
+		if i%2 == 0 {
+			r = append(r, a)
+		}
+	}
+	return r
+}
+{{range .}}{{$mode := .}}
+func TestMatrix{{.Name}}(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        {{.Test.Input}}
+		expected [][]int
+	}{ {{- range .Test.Cases}}
+		{"{{.Name}}", {{.Input}}, {{.Expected}}},{{end}}
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.Each{{.Name}}(c.v, {{with .Test.Args}}{{.}}, {{end}}func({{.Test.Callback}}) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.Map{{.Name}}(c.v, {{with .Test.Args}}{{.}}, {{end}}func({{.Test.Callback}}) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMap{{.Name}}(c.v, {{with .Test.Args}}{{.}}, {{end}}func({{.Test.Callback}}) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMap{{.Name}}(c.v, {{with .Test.Args}}{{.}}, {{end}}func({{.Test.Callback}}) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+{{end}}`))
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	var files = []struct {
+		filename string
+		src      func() ([]byte, error)
+	}{
+		{"../../pkg/slices/mappers_gen.go", func() ([]byte, error) { return render(sourceTemplate) }},
+		{"../../pkg/slices/mappers_gen_test.go", func() ([]byte, error) { return render(testTemplate) }},
+	}
+	for _, f := range files {
+		var expected, err = f.src()
+		require.That(t, err).IsNil()
+		actual, err := os.ReadFile(f.filename)
+		require.That(t, err).IsNil()
+		require.That(t, string(actual)).Eq(string(expected))
+	}
+}
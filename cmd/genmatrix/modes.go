@@ -0,0 +1,212 @@
+package main
+
+// Mode describes a traversal mode of the `slices` package, implemented by a
+// hand-written `Each<Name>` function. The generator derives the `Map<Name>`,
+// `FlatMap<Name>` and `FilterMap<Name>` composites and their tests from it.
+type Mode struct {
+	// Name of the traversal mode, as used in the name of all the functions.
+	Name string
+
+	// Doc is the beginning of the doc comment of each composite, describing
+	// how `f` is invoked; it is followed by a description of how results are
+	// collected.
+	Doc string
+
+	// TypeParams lists additional type parameters required by the mode.
+	TypeParams string
+
+	// Result is the name of the type parameter for the result elements.
+	Result string
+
+	// Input is the type of the input argument `v`.
+	Input string
+
+	// Params and Args are the extra parameters of the mode, as declared in
+	// the function signature and as forwarded to `Each<Name>`.
+	Params string
+	Args   string
+
+	// Callback and CallbackArgs are the parameters of the callback invoked by
+	// `Each<Name>`, as declared and as forwarded to `f`.
+	Callback     string
+	CallbackArgs string
+
+	// Test describes the test cases generated for the mode.
+	Test Test
+}
+
+// Test describes the test cases generated for a mode, with `T` set to `int`.
+type Test struct {
+	// Input is the type of the input for each case.
+	Input string
+
+	// Args are the extra arguments passed to all the functions of the mode,
+	// and Callback the callback parameters, where the window must be `a`.
+	Args     string
+	Callback string
+
+	// Cases are the test cases, each with an input and the expected sequence
+	// of windows passed to the callback.
+	Cases []Case
+}
+
+// Case is a single test case for a mode.
+type Case struct {
+	Name     string
+	Input    string
+	Expected string
+}
+
+// modes is the declarative list of traversal modes from which the composites
+// are generated. Adding a new traversal mode only requires implementing its
+// `Each<Name>` function and adding an entry here.
+var modes = []Mode{
+	{
+		Name:         "Cons",
+		Doc:          "invokes `f` with each `Cons(n)` of `v`",
+		Result:       "U",
+		TypeParams:   "U any",
+		Input:        "[]T",
+		Params:       "n int",
+		Args:         "n",
+		Callback:     "a []T",
+		CallbackArgs: "a",
+		Test: Test{
+			Input:    "[]int",
+			Args:     "3",
+			Callback: "a []int",
+			Cases: []Case{
+				{"empty", "[]int{}", "[][]int{}"},
+				{"shorter", "[]int{0, 1}", "[][]int{}"},
+				{"exact", "[]int{0, 1, 2}", "[][]int{{0, 1, 2}}"},
+				{"longer", "[]int{0, 1, 2, 3, 4}", "[][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}"},
+			},
+		},
+	},
+	{
+		Name:         "Slice",
+		Doc:          "invokes `f` with each `Slice(n)` of `v`",
+		Result:       "U",
+		TypeParams:   "U any",
+		Input:        "[]T",
+		Params:       "n int",
+		Args:         "n",
+		Callback:     "a []T",
+		CallbackArgs: "a",
+		Test: Test{
+			Input:    "[]int",
+			Args:     "3",
+			Callback: "a []int",
+			Cases: []Case{
+				{"empty", "[]int{}", "[][]int{}"},
+				{"shorter", "[]int{0, 1}", "[][]int{{0, 1}}"},
+				{"exact", "[]int{0, 1, 2, 3, 4, 5}", "[][]int{{0, 1, 2}, {3, 4, 5}}"},
+				{"partial", "[]int{0, 1, 2, 3}", "[][]int{{0, 1, 2}, {3}}"},
+			},
+		},
+	},
+	{
+		Name:         "Windows",
+		Doc:          "invokes `f` with each `Windows(size, step, partial, pad)` of `v`",
+		Result:       "U",
+		TypeParams:   "U any",
+		Input:        "[]T",
+		Params:       "size, step int, partial Partial, pad T",
+		Args:         "size, step, partial, pad",
+		Callback:     "a []T",
+		CallbackArgs: "a",
+		Test: Test{
+			Input:    "[]int",
+			Args:     "3, 2, slices.KeepPartial, 0",
+			Callback: "a []int",
+			Cases: []Case{
+				{"empty", "[]int{}", "[][]int{}"},
+				{"shorter", "[]int{0, 1}", "[][]int{{0, 1}}"},
+				{"exact", "[]int{0, 1, 2, 3, 4}", "[][]int{{0, 1, 2}, {2, 3, 4}}"},
+				{"partial", "[]int{0, 1, 2, 3, 4, 5}", "[][]int{{0, 1, 2}, {2, 3, 4}, {4, 5}}"},
+			},
+		},
+	},
+	{
+		Name:         "SliceBetween",
+		Doc:          "slices `v` according to `slicer`, invokes `f` with each slice",
+		Result:       "U",
+		TypeParams:   "U any",
+		Input:        "[]T",
+		Params:       "slicer func(a, b T) bool",
+		Args:         "slicer",
+		Callback:     "a []T",
+		CallbackArgs: "a",
+		Test: Test{
+			Input:    "[]int",
+			Args:     "func(a, b int) bool { return b < a }",
+			Callback: "a []int",
+			Cases: []Case{
+				{"empty", "[]int{}", "[][]int{}"},
+				{"single", "[]int{1}", "[][]int{{1}}"},
+				{"sorted", "[]int{1, 2, 3}", "[][]int{{1, 2, 3}}"},
+				{"split", "[]int{1, 2, 4, 3, 5, 0}", "[][]int{{1, 2, 4}, {3, 5}, {0}}"},
+			},
+		},
+	},
+	{
+		Name:         "SliceBy",
+		Doc:          "slices `v` according to `slicer`, invokes `f` with each slice",
+		Result:       "V",
+		TypeParams:   "U comparable, V any",
+		Input:        "[]T",
+		Params:       "slicer func(a T) U",
+		Args:         "slicer",
+		Callback:     "a []T",
+		CallbackArgs: "a",
+		Test: Test{
+			Input:    "[]int",
+			Args:     "func(a int) bool { return a%2 == 0 }",
+			Callback: "a []int",
+			Cases: []Case{
+				{"empty", "[]int{}", "[][]int{}"},
+				{"single", "[]int{1}", "[][]int{{1}}"},
+				{"split", "[]int{2, 4, 6, 3, 5, 8}", "[][]int{{2, 4, 6}, {3, 5}, {8}}"},
+			},
+		},
+	},
+	{
+		Name:         "GroupConsecutive",
+		Doc:          "groups consecutive elements of `v` by `key`, invokes `f` with each key and group",
+		Result:       "V",
+		TypeParams:   "K comparable, V any",
+		Input:        "[]T",
+		Params:       "key func(a T) K",
+		Args:         "key",
+		Callback:     "k K, a []T",
+		CallbackArgs: "k, a",
+		Test: Test{
+			Input:    "[]int",
+			Args:     "func(a int) bool { return a%2 == 0 }",
+			Callback: "_ bool, a []int",
+			Cases: []Case{
+				{"empty", "[]int{}", "[][]int{}"},
+				{"single", "[]int{1}", "[][]int{{1}}"},
+				{"split", "[]int{2, 4, 6, 3, 5, 8}", "[][]int{{2, 4, 6}, {3, 5}, {8}}"},
+			},
+		},
+	},
+	{
+		Name:         "Zip",
+		Doc:          "zips the slices of v into one tuple per matching index, invokes `f` with each tuple",
+		Result:       "U",
+		TypeParams:   "U any",
+		Input:        "[][]T",
+		Callback:     "a []T",
+		CallbackArgs: "a",
+		Test: Test{
+			Input:    "[][]int",
+			Callback: "a []int",
+			Cases: []Case{
+				{"empty", "[][]int{}", "[][]int{}"},
+				{"uneven", "[][]int{{1, 2, 3, 4}, {5, 6, 7}}", "[][]int{{1, 5}, {2, 6}, {3, 7}}"},
+				{"with-empty", "[][]int{{1, 2}, {}}", "[][]int{}"},
+			},
+		},
+	},
+}
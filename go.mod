@@ -1,6 +1,6 @@
 module github.com/maargenton/go-generics
 
-go 1.18
+go 1.23
 
 require (
 	github.com/maargenton/go-testpredicate v1.3.0
@@ -0,0 +1,11 @@
+//go:build go1.24
+
+package hashing
+
+import "hash/maphash"
+
+// Comparable returns the hash of `v` with the given seed, such that values
+// that compare equal have the same hash.
+func Comparable[T comparable](seed maphash.Seed, v T) uint64 {
+	return maphash.Comparable(seed, v)
+}
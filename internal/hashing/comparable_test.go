@@ -0,0 +1,32 @@
+package hashing_test
+
+import (
+	"hash/maphash"
+	"math"
+	"testing"
+
+	"github.com/maargenton/go-generics/internal/hashing"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+type key struct {
+	name string
+	f    float64
+	p    *int
+	v    any
+}
+
+func TestComparable(t *testing.T) {
+	var seed = maphash.MakeSeed()
+	var x = 1
+
+	var a = key{"a", 0, &x, 1}
+	var b = key{"a", math.Copysign(0, -1), &x, 1}
+	require.That(t, a == b).IsTrue()
+	require.That(t, hashing.Comparable(seed, a)).Eq(hashing.Comparable(seed, b))
+
+	var c = key{"b", 0, &x, 1}
+	require.That(t, hashing.Comparable(seed, a)).Eq(hashing.Comparable(seed, a))
+	require.That(t, hashing.Comparable(seed, a) != hashing.Comparable(seed, c)).IsTrue()
+	require.That(t, hashing.Comparable(seed, "abc")).Eq(hashing.Comparable(seed, "abc"))
+}
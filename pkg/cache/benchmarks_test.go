@@ -0,0 +1,48 @@
+package cache_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/cache"
+)
+
+// zipfKeys returns a sequence of `n` keys drawn from a Zipf distribution over
+// `keySpace` keys, so that a small number of keys account for most accesses.
+func zipfKeys(n int, keySpace uint64) []uint64 {
+	var r = rand.New(rand.NewPCG(1, 2))
+	var z = rand.NewZipf(r, 1.1, 1, keySpace-1)
+	var keys = make([]uint64, n)
+	for i := range keys {
+		keys[i] = z.Uint64()
+	}
+	return keys
+}
+
+func benchmarkSkewed(b *testing.B, c cache.Cache[uint64, uint64]) {
+	var keys = zipfKeys(1<<16, 1<<14)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var k = keys[i%len(keys)]
+		if _, ok := c.Get(k); !ok {
+			c.Put(k, k)
+		}
+	}
+	b.ReportMetric(c.Stats().HitRate(), "hit-rate")
+}
+
+func BenchmarkLRUSkewed(b *testing.B) {
+	benchmarkSkewed(b, cache.NewLRU[uint64, uint64](1024, nil))
+}
+
+func BenchmarkLFUSkewed(b *testing.B) {
+	benchmarkSkewed(b, cache.NewLFU[uint64, uint64](1024, nil))
+}
+
+func BenchmarkTwoQueueSkewed(b *testing.B) {
+	benchmarkSkewed(b, cache.NewTwoQueue[uint64, uint64](1024, nil))
+}
+
+func BenchmarkSynchronizedLRUSkewed(b *testing.B) {
+	benchmarkSkewed(b, cache.Synchronized[uint64, uint64](cache.NewLRU[uint64, uint64](1024, nil)))
+}
@@ -0,0 +1,119 @@
+// Package cache provides bounded caches with different eviction policies, all
+// implementing the common Cache interface. Caches are not safe for concurrent
+// use; wrap them with Synchronized() when needed.
+package cache
+
+import (
+	"iter"
+	"sync"
+)
+
+// Cache is the common interface of the bounded caches of this package.
+type Cache[K comparable, V any] interface {
+	// Get returns the value associated with `k` and records the access. The
+	// second return value is false if `k` is not in the cache.
+	Get(k K) (V, bool)
+
+	// Put associates `v` with `k`, evicting another entry if the cache is
+	// full.
+	Put(k K, v V)
+
+	// Remove removes `k` from the cache and returns true if it was present.
+	// The eviction callback is not invoked for removed entries.
+	Remove(k K) bool
+
+	// Len returns the number of entries in the cache.
+	Len() int
+
+	// All returns an iterator over the entries of the cache, starting with
+	// the entries the least likely to be evicted. For an LRU cache, this is
+	// in order of most recent use. The cache must not be modified during
+	// iteration, and iterating does not count as an access.
+	All() iter.Seq2[K, V]
+
+	// Stats returns the hit and miss statistics of the cache.
+	Stats() Stats
+}
+
+// Stats records the number of hits, misses and evictions of a cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRate returns the ratio of hits over the total number of lookups, or zero
+// if there were no lookups.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s *Stats) record(hit bool) {
+	if hit {
+		s.Hits++
+	} else {
+		s.Misses++
+	}
+}
+
+// Synchronized wraps `c` into a cache that is safe for concurrent use, where
+// all operations are serialized by a mutex. Iteration operates on a snapshot
+// of the cache taken when the iteration starts.
+func Synchronized[K comparable, V any](c Cache[K, V]) Cache[K, V] {
+	return &syncCache[K, V]{c: c}
+}
+
+type syncCache[K comparable, V any] struct {
+	mu sync.Mutex
+	c  Cache[K, V]
+}
+
+func (s *syncCache[K, V]) Get(k K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Get(k)
+}
+
+func (s *syncCache[K, V]) Put(k K, v V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.c.Put(k, v)
+}
+
+func (s *syncCache[K, V]) Remove(k K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Remove(k)
+}
+
+func (s *syncCache[K, V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Len()
+}
+
+func (s *syncCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.mu.Lock()
+		var entries []*entry[K, V]
+		for k, v := range s.c.All() {
+			entries = append(entries, &entry[K, V]{key: k, value: v})
+		}
+		s.mu.Unlock()
+
+		for _, e := range entries {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+func (s *syncCache[K, V]) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Stats()
+}
@@ -0,0 +1,76 @@
+package cache_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/cache"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// keys returns the keys of `c` in iteration order.
+func keys[K comparable, V any](c cache.Cache[K, V]) []K {
+	var r []K
+	for k := range c.All() {
+		r = append(r, k)
+	}
+	return r
+}
+
+func TestStatsHitRate(t *testing.T) {
+	require.That(t, cache.Stats{}.HitRate()).Eq(0.0)
+	require.That(t, cache.Stats{Hits: 3, Misses: 1}.HitRate()).Eq(0.75)
+}
+
+func TestSynchronized(t *testing.T) {
+	var constructors = map[string]func() cache.Cache[int, int]{
+		"LRU":      func() cache.Cache[int, int] { return cache.NewLRU[int, int](64, nil) },
+		"LFU":      func() cache.Cache[int, int] { return cache.NewLFU[int, int](64, nil) },
+		"TwoQueue": func() cache.Cache[int, int] { return cache.NewTwoQueue[int, int](64, nil) },
+	}
+	for name, ctor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			var c = cache.Synchronized(ctor())
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < 1000; i++ {
+						var k = (g*7 + i) % 100
+						if _, ok := c.Get(k); !ok {
+							c.Put(k, k)
+						}
+						if i%50 == 0 {
+							for range c.All() {
+							}
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+
+			var s = c.Stats()
+			require.That(t, s.Hits+s.Misses).Eq(uint64(8000))
+			require.That(t, c.Len()).Le(64)
+			require.That(t, keys(c)).Length().Eq(c.Len())
+			for k, v := range c.All() {
+				require.That(t, v).Eq(k)
+			}
+		})
+	}
+}
+
+func TestSynchronizedSnapshotTakenWhenIterationStarts(t *testing.T) {
+	var c = cache.Synchronized[int, int](cache.NewLRU[int, int](4, nil))
+	c.Put(1, 1)
+	var all = c.All()
+	c.Put(2, 2)
+
+	var r []int
+	for k := range all {
+		c.Put(k+10, k)
+		r = append(r, k)
+	}
+	require.That(t, r).Eq([]int{2, 1})
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"iter"
+	"sort"
+)
+
+// LFU is a bounded cache evicting the least frequently used entry when full.
+// Among entries with the same access count, the least recently used one is
+// evicted first. Get and Put run in O(1) time. Remove also runs in O(1) time,
+// except when it removes the last entry with the lowest access count, in which
+// case it scans the distinct access counts to find the new lowest one.
+// Iterating with All() also sorts the distinct access counts.
+type LFU[K comparable, V any] struct {
+	capacity int
+	onEvict  func(k K, v V)
+	entries  map[K]*entry[K, V]
+	freqs    map[int]*list[K, V]
+	minFreq  int
+	stats    Stats
+}
+
+var _ Cache[int, int] = (*LFU[int, int])(nil)
+
+// NewLFU creates a new LFU cache holding up to `capacity` entries. If not nil,
+// `onEvict` is invoked with each entry evicted to make room for a new one. It
+// panics if `capacity` is not strictly positive.
+func NewLFU[K comparable, V any](capacity int, onEvict func(k K, v V)) *LFU[K, V] {
+	mustBePositive(capacity)
+	return &LFU[K, V]{
+		capacity: capacity,
+		onEvict:  onEvict,
+		entries:  make(map[K]*entry[K, V], capacity),
+		freqs:    make(map[int]*list[K, V]),
+	}
+}
+
+// Get returns the value associated with `k` and increments its access count.
+// The second return value is false if `k` is not in the cache.
+func (c *LFU[K, V]) Get(k K) (v V, ok bool) {
+	var e, found = c.entries[k]
+	c.stats.record(found)
+	if !found {
+		return v, false
+	}
+	c.touch(e)
+	return e.value, true
+}
+
+// Put associates `v` with `k` and increments its access count, evicting the
+// least frequently used entry if the cache is full.
+func (c *LFU[K, V]) Put(k K, v V) {
+	if e, ok := c.entries[k]; ok {
+		e.value = v
+		c.touch(e)
+		return
+	}
+	if len(c.entries) >= c.capacity {
+		var l = c.freqs[c.minFreq]
+		var e = l.back()
+		c.unlink(e)
+		delete(c.entries, e.key)
+		c.stats.Evictions++
+		if c.onEvict != nil {
+			c.onEvict(e.key, e.value)
+		}
+	}
+	var e = &entry[K, V]{key: k, value: v, freq: 1}
+	c.entries[k] = e
+	c.bucket(1).pushFront(e)
+	c.minFreq = 1
+}
+
+// Remove removes `k` from the cache and returns true if it was present.
+func (c *LFU[K, V]) Remove(k K) bool {
+	var e, ok = c.entries[k]
+	if !ok {
+		return false
+	}
+	delete(c.entries, k)
+	if c.unlink(e) && c.minFreq == e.freq {
+		// Removal is the only operation that can leave a gap above the
+		// minimum frequency, so the new minimum must be searched for.
+		c.minFreq = 0
+		for f := range c.freqs {
+			if c.minFreq == 0 || f < c.minFreq {
+				c.minFreq = f
+			}
+		}
+	}
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *LFU[K, V]) Len() int {
+	return len(c.entries)
+}
+
+// All returns an iterator over the entries of the cache, from most to least
+// frequently used, and from most to least recently used among entries with
+// the same access count.
+func (c *LFU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var freqs = make([]int, 0, len(c.freqs))
+		for f := range c.freqs {
+			freqs = append(freqs, f)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(freqs)))
+		for _, f := range freqs {
+			var more = c.freqs[f].each(func(e *entry[K, V]) bool {
+				return yield(e.key, e.value)
+			})
+			if !more {
+				return
+			}
+		}
+	}
+}
+
+// Stats returns the hit and miss statistics of the cache.
+func (c *LFU[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *LFU[K, V]) touch(e *entry[K, V]) {
+	if c.unlink(e) && c.minFreq == e.freq {
+		c.minFreq++
+	}
+	e.freq++
+	c.bucket(e.freq).pushFront(e)
+}
+
+// unlink removes `e` from its frequency bucket, discarding the bucket once
+// empty, and returns true if the bucket was discarded.
+func (c *LFU[K, V]) unlink(e *entry[K, V]) bool {
+	var l = c.freqs[e.freq]
+	l.remove(e)
+	if l.len == 0 {
+		delete(c.freqs, e.freq)
+		return true
+	}
+	return false
+}
+
+func (c *LFU[K, V]) bucket(freq int) *list[K, V] {
+	var l, ok = c.freqs[freq]
+	if !ok {
+		l = &list[K, V]{}
+		c.freqs[freq] = l
+	}
+	return l
+}
@@ -0,0 +1,84 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/cache"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestLFU(t *testing.T) {
+	var evicted []int
+	var c = cache.NewLFU(3, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+	c.Get(1)
+	c.Get(3)
+
+	c.Put(4, 4) // 2 is the least frequently used
+	require.That(t, evicted).Eq([]int{2})
+	c.Put(5, 5) // 4 is tied with nothing below, evicted as least frequent
+	require.That(t, evicted).Eq([]int{2, 4})
+	require.That(t, keys[int, int](c)).Eq([]int{1, 3, 5})
+	require.That(t, c.Stats()).Eq(cache.Stats{Hits: 3, Evictions: 2})
+}
+
+func TestLFUTiesEvictLeastRecentlyUsed(t *testing.T) {
+	var evicted []int
+	var c = cache.NewLFU(2, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(1)
+	c.Get(2)
+	c.Put(3, 3)
+	c.Put(4, 4)
+	require.That(t, evicted).Eq([]int{1, 3})
+}
+
+func TestLFURemove(t *testing.T) {
+	var evicted []int
+	var c = cache.NewLFU(3, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(2)
+	c.Get(2)
+	c.Get(3)
+	require.That(t, c.Remove(1)).IsTrue()
+	require.That(t, c.Remove(1)).IsFalse()
+	require.That(t, c.Len()).Eq(2)
+
+	c.Put(4, 4)
+	c.Put(5, 5) // 4 is the only entry with a count of 1
+	require.That(t, evicted).Eq([]int{4})
+	require.That(t, keys[int, int](c)).Eq([]int{2, 3, 5})
+}
+
+func TestLFURemoveMinimum(t *testing.T) {
+	var evicted []int
+	var c = cache.NewLFU(2, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(2)
+	c.Get(2)
+	c.Remove(1)
+	c.Get(2)
+	require.That(t, keys[int, int](c)).Eq([]int{2})
+	c.Put(3, 3)
+	c.Put(4, 4)
+	require.That(t, evicted).Eq([]int{3})
+}
+
+func TestNewLFUPanicsOnInvalidCapacity(t *testing.T) {
+	require.That(t, func() { cache.NewLFU[int, int](-1, nil) }).Panics()
+}
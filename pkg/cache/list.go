@@ -0,0 +1,68 @@
+package cache
+
+// entry is a cache entry, linked into one of the lists of a cache.
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	freq       int  // access count, used by LFU
+	hot        bool // in the frequent queue, used by TwoQueue
+	prev, next *entry[K, V]
+}
+
+// list is an intrusive doubly-linked list of entries, with the most recently
+// pushed entry at the front. The zero value is an empty list ready to use.
+type list[K comparable, V any] struct {
+	root entry[K, V]
+	len  int
+}
+
+func (l *list[K, V]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+func (l *list[K, V]) pushFront(e *entry[K, V]) {
+	l.lazyInit()
+	e.prev = &l.root
+	e.next = l.root.next
+	e.prev.next = e
+	e.next.prev = e
+	l.len++
+}
+
+func (l *list[K, V]) remove(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	l.len--
+}
+
+func (l *list[K, V]) moveToFront(e *entry[K, V]) {
+	l.remove(e)
+	l.pushFront(e)
+}
+
+// back returns the least recently pushed entry, or nil if the list is empty.
+func (l *list[K, V]) back() *entry[K, V] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// each invokes `f` with each entry from front to back, until `f` returns
+// false; it returns false if the iteration was interrupted.
+func (l *list[K, V]) each(f func(e *entry[K, V]) bool) bool {
+	if l.len == 0 {
+		return true
+	}
+	for e := l.root.next; e != &l.root; e = e.next {
+		if !f(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package cache
+
+import "iter"
+
+// LRU is a bounded cache evicting the least recently used entry when full.
+type LRU[K comparable, V any] struct {
+	capacity int
+	onEvict  func(k K, v V)
+	entries  map[K]*entry[K, V]
+	order    list[K, V]
+	stats    Stats
+}
+
+var _ Cache[int, int] = (*LRU[int, int])(nil)
+
+// NewLRU creates a new LRU cache holding up to `capacity` entries. If not nil,
+// `onEvict` is invoked with each entry evicted to make room for a new one. It
+// panics if `capacity` is not strictly positive.
+func NewLRU[K comparable, V any](capacity int, onEvict func(k K, v V)) *LRU[K, V] {
+	mustBePositive(capacity)
+	return &LRU[K, V]{
+		capacity: capacity,
+		onEvict:  onEvict,
+		entries:  make(map[K]*entry[K, V], capacity),
+	}
+}
+
+// Get returns the value associated with `k` and marks it as most recently
+// used. The second return value is false if `k` is not in the cache.
+func (c *LRU[K, V]) Get(k K) (v V, ok bool) {
+	var e, found = c.entries[k]
+	c.stats.record(found)
+	if !found {
+		return v, false
+	}
+	c.order.moveToFront(e)
+	return e.value, true
+}
+
+// Put associates `v` with `k` and marks it as most recently used, evicting the
+// least recently used entry if the cache is full.
+func (c *LRU[K, V]) Put(k K, v V) {
+	if e, ok := c.entries[k]; ok {
+		e.value = v
+		c.order.moveToFront(e)
+		return
+	}
+	if c.order.len >= c.capacity {
+		var e = c.order.back()
+		c.order.remove(e)
+		delete(c.entries, e.key)
+		c.stats.Evictions++
+		if c.onEvict != nil {
+			c.onEvict(e.key, e.value)
+		}
+	}
+	var e = &entry[K, V]{key: k, value: v}
+	c.entries[k] = e
+	c.order.pushFront(e)
+}
+
+// Remove removes `k` from the cache and returns true if it was present.
+func (c *LRU[K, V]) Remove(k K) bool {
+	var e, ok = c.entries[k]
+	if ok {
+		c.order.remove(e)
+		delete(c.entries, k)
+	}
+	return ok
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	return c.order.len
+}
+
+// All returns an iterator over the entries of the cache, from most to least
+// recently used.
+func (c *LRU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.order.each(func(e *entry[K, V]) bool {
+			return yield(e.key, e.value)
+		})
+	}
+}
+
+// Stats returns the hit and miss statistics of the cache.
+func (c *LRU[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func mustBePositive(capacity int) {
+	if capacity <= 0 {
+		panic("cache: capacity must be strictly positive")
+	}
+}
@@ -0,0 +1,78 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/cache"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestLRU(t *testing.T) {
+	var evicted []int
+	var c = cache.NewLRU(3, func(k int, v string) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(3, "c")
+	require.That(t, keys[int, string](c)).Eq([]int{3, 2, 1})
+
+	var v, ok = c.Get(1)
+	require.That(t, v).Eq("a")
+	require.That(t, ok).IsTrue()
+	require.That(t, keys[int, string](c)).Eq([]int{1, 3, 2})
+
+	c.Put(4, "d")
+	require.That(t, evicted).Eq([]int{2})
+	require.That(t, keys[int, string](c)).Eq([]int{4, 1, 3})
+
+	_, ok = c.Get(2)
+	require.That(t, ok).IsFalse()
+	require.That(t, c.Stats()).Eq(cache.Stats{Hits: 1, Misses: 1, Evictions: 1})
+}
+
+func TestLRUPutExisting(t *testing.T) {
+	var c = cache.NewLRU[int, string](2, nil)
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(1, "A")
+	c.Put(3, "c")
+	require.That(t, keys[int, string](c)).Eq([]int{3, 1})
+	var v, _ = c.Get(1)
+	require.That(t, v).Eq("A")
+}
+
+func TestLRURemove(t *testing.T) {
+	var evicted []int
+	var c = cache.NewLRU(2, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, 1)
+	c.Put(2, 2)
+	require.That(t, c.Remove(1)).IsTrue()
+	require.That(t, c.Remove(1)).IsFalse()
+	require.That(t, c.Len()).Eq(1)
+	c.Put(3, 3)
+	require.That(t, evicted).IsEmpty()
+	require.That(t, keys[int, int](c)).Eq([]int{3, 2})
+}
+
+func TestLRUAllStopsEarly(t *testing.T) {
+	var c = cache.NewLRU[int, int](4, nil)
+	for i := 0; i < 4; i++ {
+		c.Put(i, i)
+	}
+	var r []int
+	for k := range c.All() {
+		r = append(r, k)
+		if len(r) == 2 {
+			break
+		}
+	}
+	require.That(t, r).Eq([]int{3, 2})
+}
+
+func TestNewLRUPanicsOnInvalidCapacity(t *testing.T) {
+	require.That(t, func() { cache.NewLRU[int, int](0, nil) }).
+		PanicsAndRecoveredValue().Eq("cache: capacity must be strictly positive")
+}
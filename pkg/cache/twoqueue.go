@@ -0,0 +1,151 @@
+package cache
+
+import "iter"
+
+// TwoQueue is a bounded cache implementing the full 2Q replacement policy.
+// New entries are admitted into a small FIFO queue of recent entries; entries
+// evicted from that queue are remembered as ghost keys, and entries that are
+// put again while remembered are promoted to the main LRU queue of frequent
+// entries. This makes the cache resistant to scans of items used only once.
+type TwoQueue[K comparable, V any] struct {
+	capacity   int
+	recentCap  int
+	ghostCap   int
+	onEvict    func(k K, v V)
+	entries    map[K]*entry[K, V]
+	ghosts     map[K]*entry[K, V]
+	recent     list[K, V]
+	frequent   list[K, V]
+	ghostOrder list[K, V]
+	stats      Stats
+}
+
+var _ Cache[int, int] = (*TwoQueue[int, int])(nil)
+
+// NewTwoQueue creates a new 2Q cache holding up to `capacity` entries, with
+// the recommended tuning of 25% of the capacity for the queue of recent
+// entries and 50% for ghost keys. If not nil, `onEvict` is invoked with each
+// entry evicted to make room for a new one. It panics if `capacity` is not
+// strictly positive.
+func NewTwoQueue[K comparable, V any](capacity int, onEvict func(k K, v V)) *TwoQueue[K, V] {
+	mustBePositive(capacity)
+	return &TwoQueue[K, V]{
+		capacity:  capacity,
+		recentCap: max(capacity/4, 1),
+		ghostCap:  max(capacity/2, 1),
+		onEvict:   onEvict,
+		entries:   make(map[K]*entry[K, V], capacity),
+		ghosts:    make(map[K]*entry[K, V]),
+	}
+}
+
+// Get returns the value associated with `k`, marking it as most recently used
+// if it is in the queue of frequent entries. The second return value is false
+// if `k` is not in the cache.
+func (c *TwoQueue[K, V]) Get(k K) (v V, ok bool) {
+	var e, found = c.entries[k]
+	c.stats.record(found)
+	if !found {
+		return v, false
+	}
+	if e.hot {
+		c.frequent.moveToFront(e)
+	}
+	return e.value, true
+}
+
+// Put associates `v` with `k`. New keys are admitted into the queue of recent
+// entries, unless they were recently evicted from it, in which case they are
+// promoted to the queue of frequent entries.
+func (c *TwoQueue[K, V]) Put(k K, v V) {
+	if e, ok := c.entries[k]; ok {
+		e.value = v
+		if e.hot {
+			c.frequent.moveToFront(e)
+		}
+		return
+	}
+
+	var e = &entry[K, V]{key: k, value: v}
+	if g, ok := c.ghosts[k]; ok {
+		c.ghostOrder.remove(g)
+		delete(c.ghosts, k)
+		e.hot = true
+		c.frequent.pushFront(e)
+	} else {
+		c.recent.pushFront(e)
+	}
+	c.entries[k] = e
+	if len(c.entries) > c.capacity {
+		c.evict()
+	}
+}
+
+func (c *TwoQueue[K, V]) evict() {
+	var e *entry[K, V]
+	if c.recent.len > c.recentCap || c.frequent.len == 0 {
+		e = c.recent.back()
+		c.recent.remove(e)
+
+		var g = &entry[K, V]{key: e.key}
+		c.ghosts[e.key] = g
+		c.ghostOrder.pushFront(g)
+		if c.ghostOrder.len > c.ghostCap {
+			var old = c.ghostOrder.back()
+			c.ghostOrder.remove(old)
+			delete(c.ghosts, old.key)
+		}
+	} else {
+		e = c.frequent.back()
+		c.frequent.remove(e)
+	}
+	delete(c.entries, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+// Remove removes `k` from the cache and returns true if it was present. Any
+// ghost key for `k` is also forgotten.
+func (c *TwoQueue[K, V]) Remove(k K) bool {
+	if g, ok := c.ghosts[k]; ok {
+		c.ghostOrder.remove(g)
+		delete(c.ghosts, k)
+	}
+	var e, ok = c.entries[k]
+	if !ok {
+		return false
+	}
+	if e.hot {
+		c.frequent.remove(e)
+	} else {
+		c.recent.remove(e)
+	}
+	delete(c.entries, k)
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *TwoQueue[K, V]) Len() int {
+	return len(c.entries)
+}
+
+// All returns an iterator over the entries of the cache, starting with the
+// frequent entries from most to least recently used, followed by the recent
+// entries from newest to oldest.
+func (c *TwoQueue[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var f = func(e *entry[K, V]) bool {
+			return yield(e.key, e.value)
+		}
+		if c.frequent.each(f) {
+			c.recent.each(f)
+		}
+	}
+}
+
+// Stats returns the hit and miss statistics of the cache.
+func (c *TwoQueue[K, V]) Stats() Stats {
+	return c.stats
+}
@@ -0,0 +1,68 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/cache"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestTwoQueue(t *testing.T) {
+	var evicted []int
+	var c = cache.NewTwoQueue(4, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	for i := 1; i <= 4; i++ {
+		c.Put(i, i)
+	}
+	c.Put(5, 5) // recent queue over its share, oldest recent entry evicted
+	require.That(t, evicted).Eq([]int{1})
+
+	c.Put(1, 1) // remembered as ghost, promoted to frequent
+	require.That(t, evicted).Eq([]int{1, 2})
+	require.That(t, keys[int, int](c)).Eq([]int{1, 5, 4, 3})
+
+	var v, ok = c.Get(1)
+	require.That(t, v).Eq(1)
+	require.That(t, ok).IsTrue()
+	require.That(t, c.Stats()).Eq(cache.Stats{Hits: 1, Evictions: 2})
+}
+
+func TestTwoQueueResistsScans(t *testing.T) {
+	var c = cache.NewTwoQueue[int, int](8, nil)
+	var hot = []int{1, 2, 3}
+	for _, k := range hot {
+		c.Put(k, k)
+	}
+	for i := 100; i < 108; i++ {
+		c.Put(i, i) // pushes hot keys out of the recent queue
+	}
+	for _, k := range hot {
+		c.Put(k, k) // promoted to frequent
+	}
+	for i := 200; i < 300; i++ {
+		c.Put(i, i)
+	}
+	for _, k := range hot {
+		var _, ok = c.Get(k)
+		require.That(t, ok).IsTrue()
+	}
+}
+
+func TestTwoQueueRemove(t *testing.T) {
+	var c = cache.NewTwoQueue[int, int](4, nil)
+	for i := 1; i <= 5; i++ {
+		c.Put(i, i)
+	}
+	require.That(t, c.Remove(1)).IsFalse() // only a ghost
+	c.Put(1, 1)                            // no longer remembered
+	require.That(t, keys[int, int](c)).Eq([]int{1, 5, 4, 3})
+
+	require.That(t, c.Remove(4)).IsTrue()
+	require.That(t, c.Remove(4)).IsFalse()
+	require.That(t, c.Len()).Eq(3)
+}
+
+func TestNewTwoQueuePanicsOnInvalidCapacity(t *testing.T) {
+	require.That(t, func() { cache.NewTwoQueue[int, int](0, nil) }).Panics()
+}
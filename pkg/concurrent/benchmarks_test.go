@@ -0,0 +1,68 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/concurrent"
+)
+
+// The benchmarks below run the same mix of operations against SyncMap and
+// sync.Map, from parallel goroutines contending over a small key space. The
+// `writes` parameter is the percentage of operations that are stores.
+
+const benchKeys = 1024
+
+func benchmarkSyncMap(b *testing.B, writes int) {
+	var m = concurrent.New[int, int]()
+	for i := 0; i < benchKeys; i++ {
+		m.Store(i, i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		var i = 0
+		for pb.Next() {
+			var k = (i * 7919) % benchKeys
+			if i%100 < writes {
+				m.Store(k, i)
+			} else {
+				m.Load(k)
+			}
+			i++
+		}
+	})
+}
+
+func benchmarkStdSyncMap(b *testing.B, writes int) {
+	var m sync.Map
+	for i := 0; i < benchKeys; i++ {
+		m.Store(i, i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		var i = 0
+		for pb.Next() {
+			var k = (i * 7919) % benchKeys
+			if i%100 < writes {
+				m.Store(k, i)
+			} else {
+				m.Load(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapReadHeavy(b *testing.B) {
+	benchmarkSyncMap(b, 10)
+}
+
+func BenchmarkStdSyncMapReadHeavy(b *testing.B) {
+	benchmarkStdSyncMap(b, 10)
+}
+
+func BenchmarkSyncMapWriteHeavy(b *testing.B) {
+	benchmarkSyncMap(b, 90)
+}
+
+func BenchmarkStdSyncMapWriteHeavy(b *testing.B) {
+	benchmarkStdSyncMap(b, 90)
+}
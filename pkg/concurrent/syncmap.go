@@ -0,0 +1,246 @@
+// Package concurrent provides typed map and set containers that are safe for
+// concurrent use. Unlike `sync.Map`, they are sharded with a read-write mutex
+// per shard, which makes them better suited to write-heavy workloads, and
+// support consistent snapshots that can be processed with the functions of
+// the `maps` package.
+package concurrent
+
+import (
+	"hash/maphash"
+	"iter"
+	"sync"
+
+	"github.com/maargenton/go-generics/internal/hashing"
+	"github.com/maargenton/go-generics/pkg/maps"
+)
+
+// DefaultShards is the number of shards used by New().
+const DefaultShards = 32
+
+// SyncMap is a typed map safe for concurrent use, where keys are distributed
+// across independently locked shards. The zero value is not usable; use New()
+// or NewSharded() to create one.
+type SyncMap[K comparable, V any] struct {
+	seed   maphash.Seed
+	mask   uint64
+	shards []shard[K, V]
+}
+
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// New creates a new empty SyncMap with DefaultShards shards.
+func New[K comparable, V any]() *SyncMap[K, V] {
+	return NewSharded[K, V](DefaultShards)
+}
+
+// NewSharded creates a new empty SyncMap with at least `n` shards, rounded up
+// to the next power of two. It panics if `n` is not strictly positive.
+func NewSharded[K comparable, V any](n int) *SyncMap[K, V] {
+	if n <= 0 {
+		panic("concurrent: shard count must be strictly positive")
+	}
+	var size = 1
+	for size < n {
+		size <<= 1
+	}
+	var m = &SyncMap[K, V]{
+		seed:   maphash.MakeSeed(),
+		mask:   uint64(size - 1),
+		shards: make([]shard[K, V], size),
+	}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+func (m *SyncMap[K, V]) shard(k K) *shard[K, V] {
+	return &m.shards[hashing.Comparable(m.seed, k)&m.mask]
+}
+
+// Load returns the value associated with `k`. The second return value is
+// false if `k` is not in the map.
+func (m *SyncMap[K, V]) Load(k K) (v V, ok bool) {
+	var s = m.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok = s.m[k]
+	return v, ok
+}
+
+// Store associates `v` with `k`.
+func (m *SyncMap[K, V]) Store(k K, v V) {
+	var s = m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = v
+}
+
+// LoadOrStore returns the value associated with `k` if present. Otherwise, it
+// stores and returns `v`. The second return value is true if the value was
+// loaded, false if stored.
+func (m *SyncMap[K, V]) LoadOrStore(k K, v V) (actual V, loaded bool) {
+	var s = m.shard(k)
+	s.mu.RLock()
+	actual, loaded = s.m[k]
+	s.mu.RUnlock()
+	if loaded {
+		return actual, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if actual, loaded = s.m[k]; loaded {
+		return actual, true
+	}
+	s.m[k] = v
+	return v, false
+}
+
+// LoadAndDelete removes `k` from the map and returns its previous value. The
+// second return value is false if `k` was not in the map.
+func (m *SyncMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	var s = m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, loaded = s.m[k]
+	delete(s.m, k)
+	return v, loaded
+}
+
+// Delete removes `k` from the map.
+func (m *SyncMap[K, V]) Delete(k K) {
+	var s = m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, k)
+}
+
+// Compute atomically replaces the value associated with `k` with the result of
+// `f`, invoked with the current value and whether `k` is present. If `f`
+// returns false as second value, `k` is removed from the map instead. Compute
+// returns the new value and whether `k` is present after the update. `f` is
+// invoked with the shard locked and must not access the map.
+func (m *SyncMap[K, V]) Compute(k K, f func(v V, ok bool) (V, bool)) (V, bool) {
+	var s = m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var v, ok = s.m[k]
+	v, ok = f(v, ok)
+	if ok {
+		s.m[k] = v
+	} else {
+		delete(s.m, k)
+	}
+	return v, ok
+}
+
+// Update atomically replaces the value associated with `k` with the result of
+// `f`, invoked with the current value. It does nothing and returns false if
+// `k` is not in the map. `f` is invoked with the shard locked and must not
+// access the map.
+func (m *SyncMap[K, V]) Update(k K, f func(v V) V) bool {
+	var s = m.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var v, ok = s.m[k]
+	if ok {
+		s.m[k] = f(v)
+	}
+	return ok
+}
+
+// Len returns the number of entries in the map. Concurrent updates to other
+// shards can happen while counting, so the result is only exact in the
+// absence of concurrent writes.
+func (m *SyncMap[K, V]) Len() int {
+	var n = 0
+	for i := range m.shards {
+		var s = &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Snapshot returns a copy of the content of the map, consistent across all
+// shards. All shards are locked for reading while the copy is made.
+func (m *SyncMap[K, V]) Snapshot() map[K]V {
+	for i := range m.shards {
+		m.shards[i].mu.RLock()
+	}
+	var n = 0
+	for i := range m.shards {
+		n += len(m.shards[i].m)
+	}
+	var r = make(map[K]V, n)
+	for i := range m.shards {
+		for k, v := range m.shards[i].m {
+			r[k] = v
+		}
+		m.shards[i].mu.RUnlock()
+	}
+	return r
+}
+
+// Range invokes `f` with each key-value pair of a snapshot of the map, until
+// `f` returns false. Since it operates on a snapshot, `f` is free to modify
+// the map.
+func (m *SyncMap[K, V]) Range(f func(k K, v V) bool) {
+	for k, v := range m.Snapshot() {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the key-value pairs of a snapshot of the map,
+// taken when the iteration starts.
+func (m *SyncMap[K, V]) All() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// CompareAndSwap atomically replaces the value associated with `k` with `new`
+// if its current value is equal to `old`, and returns true if the swap was
+// performed.
+func CompareAndSwap[K comparable, V comparable](m *SyncMap[K, V], k K, old, new V) bool {
+	var swapped = false
+	m.Update(k, func(v V) V {
+		if v != old {
+			return v
+		}
+		swapped = true
+		return new
+	})
+	return swapped
+}
+
+// CompareAndDelete atomically removes `k` from the map if its current value
+// is equal to `old`, and returns true if it was removed.
+func CompareAndDelete[K comparable, V comparable](m *SyncMap[K, V], k K, old V) bool {
+	var deleted = false
+	m.Compute(k, func(v V, ok bool) (V, bool) {
+		if ok && v == old {
+			deleted = true
+			return v, false
+		}
+		return v, ok
+	})
+	return deleted
+}
+
+// Map invokes `maps.Map()` on a consistent snapshot of `m`.
+func Map[K comparable, V any, R comparable, S any](
+	m *SyncMap[K, V], f func(k K, v V) (R, S)) map[R]S {
+	return maps.Map(m.Snapshot(), f)
+}
+
+// Filter invokes `maps.Filter()` on a consistent snapshot of `m`.
+func Filter[K comparable, V any](
+	m *SyncMap[K, V], f func(k K, v V) bool) map[K]V {
+	return maps.Filter(m.Snapshot(), f)
+}
@@ -0,0 +1,165 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/concurrent"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestSyncMapLoadStoreDelete(t *testing.T) {
+	var m = concurrent.New[string, int]()
+	m.Store("a", 1)
+	var v, ok = m.Load("a")
+	require.That(t, v).Eq(1)
+	require.That(t, ok).IsTrue()
+
+	_, ok = m.Load("b")
+	require.That(t, ok).IsFalse()
+
+	v, ok = m.LoadAndDelete("a")
+	require.That(t, v).Eq(1)
+	require.That(t, ok).IsTrue()
+	_, ok = m.LoadAndDelete("a")
+	require.That(t, ok).IsFalse()
+
+	m.Store("c", 3)
+	m.Delete("c")
+	require.That(t, m.Len()).Eq(0)
+}
+
+func TestSyncMapLoadOrStore(t *testing.T) {
+	var m = concurrent.New[string, int]()
+	var v, loaded = m.LoadOrStore("a", 1)
+	require.That(t, v).Eq(1)
+	require.That(t, loaded).IsFalse()
+
+	v, loaded = m.LoadOrStore("a", 2)
+	require.That(t, v).Eq(1)
+	require.That(t, loaded).IsTrue()
+}
+
+func TestSyncMapCompute(t *testing.T) {
+	var m = concurrent.New[string, int]()
+	var incr = func(v int, ok bool) (int, bool) { return v + 1, true }
+	m.Compute("a", incr)
+	var v, ok = m.Compute("a", incr)
+	require.That(t, v).Eq(2)
+	require.That(t, ok).IsTrue()
+
+	_, ok = m.Compute("a", func(v int, ok bool) (int, bool) { return 0, false })
+	require.That(t, ok).IsFalse()
+	require.That(t, m.Len()).Eq(0)
+}
+
+func TestSyncMapUpdate(t *testing.T) {
+	var m = concurrent.New[string, int]()
+	var double = func(v int) int { return v * 2 }
+	require.That(t, m.Update("a", double)).IsFalse()
+	require.That(t, m.Len()).Eq(0)
+
+	m.Store("a", 21)
+	require.That(t, m.Update("a", double)).IsTrue()
+	var v, _ = m.Load("a")
+	require.That(t, v).Eq(42)
+}
+
+func TestSyncMapCompareAndSwap(t *testing.T) {
+	var m = concurrent.New[string, int]()
+	require.That(t, concurrent.CompareAndSwap(m, "a", 0, 1)).IsFalse()
+	require.That(t, m.Len()).Eq(0)
+
+	m.Store("a", 1)
+	require.That(t, concurrent.CompareAndSwap(m, "a", 2, 3)).IsFalse()
+	require.That(t, concurrent.CompareAndSwap(m, "a", 1, 3)).IsTrue()
+	require.That(t, concurrent.CompareAndDelete(m, "a", 1)).IsFalse()
+	require.That(t, concurrent.CompareAndDelete(m, "a", 3)).IsTrue()
+	require.That(t, m.Len()).Eq(0)
+}
+
+func TestSyncMapSnapshot(t *testing.T) {
+	var m = concurrent.NewSharded[int, int](3)
+	for i := 0; i < 100; i++ {
+		m.Store(i, i*i)
+	}
+	var snapshot = m.Snapshot()
+	require.That(t, snapshot).Length().Eq(100)
+	require.That(t, snapshot[7]).Eq(49)
+
+	var n = 0
+	m.Range(func(k, v int) bool {
+		m.Delete(k) // safe, iterating over a snapshot
+		n++
+		return n < 10
+	})
+	require.That(t, n).Eq(10)
+	require.That(t, m.Len()).Eq(90)
+
+	n = 0
+	for range m.All() {
+		n++
+	}
+	require.That(t, n).Eq(90)
+}
+
+func TestSyncMapAdapters(t *testing.T) {
+	var m = concurrent.New[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Store(i, i*i)
+	}
+	var even = concurrent.Filter(m, func(k, v int) bool { return k%2 == 0 })
+	require.That(t, even).Eq(map[int]int{0: 0, 2: 4, 4: 16, 6: 36, 8: 64})
+
+	var swapped = concurrent.Map(m, func(k, v int) (int, int) { return v, k })
+	require.That(t, swapped).Length().Eq(10)
+	require.That(t, swapped[81]).Eq(9)
+}
+
+func TestNewShardedPanicsOnInvalidCount(t *testing.T) {
+	require.That(t, func() { concurrent.NewSharded[int, int](0) }).
+		PanicsAndRecoveredValue().Eq("concurrent: shard count must be strictly positive")
+}
+
+func TestSyncMapConcurrentAccess(t *testing.T) {
+	var m = concurrent.NewSharded[int, int](4)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				var k = i % 50
+				m.Compute(k, func(v int, ok bool) (int, bool) { return v + 1, true })
+				m.LoadOrStore(k+100, g)
+				for {
+					var v, ok = m.Load(k + 200)
+					if !ok {
+						if _, loaded := m.LoadOrStore(k+200, 1); !loaded {
+							break
+						}
+						continue
+					}
+					if concurrent.CompareAndSwap(m, k+200, v, v+1) {
+						break
+					}
+				}
+				if i%100 == 0 {
+					m.Snapshot()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	var computed, swapped = 0, 0
+	for k := 0; k < 50; k++ {
+		var v, _ = m.Load(k)
+		computed += v
+		v, _ = m.Load(k + 200)
+		swapped += v
+	}
+	require.That(t, computed).Eq(8000)
+	require.That(t, swapped).Eq(8000)
+	require.That(t, m.Len()).Eq(150)
+}
@@ -0,0 +1,70 @@
+package concurrent
+
+import "iter"
+
+// SyncSet is a typed set safe for concurrent use, built on top of SyncMap. The
+// zero value is not usable; use NewSet() or NewShardedSet() to create one.
+type SyncSet[T comparable] struct {
+	m *SyncMap[T, struct{}]
+}
+
+// NewSet creates a new empty SyncSet with DefaultShards shards.
+func NewSet[T comparable]() *SyncSet[T] {
+	return &SyncSet[T]{m: New[T, struct{}]()}
+}
+
+// NewShardedSet creates a new empty SyncSet with at least `n` shards, rounded
+// up to the next power of two. It panics if `n` is not strictly positive.
+func NewShardedSet[T comparable](n int) *SyncSet[T] {
+	return &SyncSet[T]{m: NewSharded[T, struct{}](n)}
+}
+
+// Add inserts `v` into the set and returns true if it was not already
+// present.
+func (s *SyncSet[T]) Add(v T) bool {
+	var _, loaded = s.m.LoadOrStore(v, struct{}{})
+	return !loaded
+}
+
+// Remove removes `v` from the set and returns true if it was present.
+func (s *SyncSet[T]) Remove(v T) bool {
+	var _, loaded = s.m.LoadAndDelete(v)
+	return loaded
+}
+
+// Contains returns true if `v` is in the set.
+func (s *SyncSet[T]) Contains(v T) bool {
+	var _, ok = s.m.Load(v)
+	return ok
+}
+
+// Len returns the number of elements in the set, with the same caveat as
+// SyncMap.Len() under concurrent writes.
+func (s *SyncSet[T]) Len() int {
+	return s.m.Len()
+}
+
+// Snapshot returns the elements of the set, in no particular order, from a
+// copy consistent across all shards.
+func (s *SyncSet[T]) Snapshot() []T {
+	var snapshot = s.m.Snapshot()
+	var r = make([]T, 0, len(snapshot))
+	for v := range snapshot {
+		r = append(r, v)
+	}
+	return r
+}
+
+// Range invokes `f` with each element of a snapshot of the set, until `f`
+// returns false.
+func (s *SyncSet[T]) Range(f func(v T) bool) {
+	s.m.Range(func(v T, _ struct{}) bool {
+		return f(v)
+	})
+}
+
+// All returns an iterator over the elements of a snapshot of the set, taken
+// when the iteration starts.
+func (s *SyncSet[T]) All() iter.Seq[T] {
+	return s.Range
+}
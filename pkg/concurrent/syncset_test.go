@@ -0,0 +1,63 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/concurrent"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestSyncSet(t *testing.T) {
+	var s = concurrent.NewSet[string]()
+	require.That(t, s.Add("a")).IsTrue()
+	require.That(t, s.Add("a")).IsFalse()
+	require.That(t, s.Add("b")).IsTrue()
+	require.That(t, s.Contains("a")).IsTrue()
+	require.That(t, s.Contains("c")).IsFalse()
+	require.That(t, s.Len()).Eq(2)
+	require.That(t, s.Snapshot()).IsEqualSet([]string{"a", "b"})
+
+	require.That(t, s.Remove("a")).IsTrue()
+	require.That(t, s.Remove("a")).IsFalse()
+	require.That(t, s.Snapshot()).Eq([]string{"b"})
+}
+
+func TestSyncSetRange(t *testing.T) {
+	var s = concurrent.NewShardedSet[int](2)
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+	var r []int
+	for v := range s.All() {
+		s.Remove(v)
+		r = append(r, v)
+	}
+	require.That(t, r).IsEqualSet([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	require.That(t, s.Len()).Eq(0)
+}
+
+func TestSyncSetConcurrentAdd(t *testing.T) {
+	var s = concurrent.NewSet[int]()
+	var added = make([]int, 8)
+	var wg sync.WaitGroup
+	for g := range added {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if s.Add(i) {
+					added[g]++
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	var total = 0
+	for _, n := range added {
+		total += n
+	}
+	require.That(t, total).Eq(1000)
+	require.That(t, s.Len()).Eq(1000)
+}
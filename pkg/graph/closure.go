@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// TransitiveClosure returns a new adjacency map with an edge a -> b for every
+// pair of nodes where `b` is reachable from `a` through at least one edge.
+// Every node of `g` is a key of the result, and successors are sorted in
+// increasing order. A node is its own successor only if it is part of a
+// cycle.
+func TransitiveClosure[K constraints.Ordered](g map[K][]K) map[K][]K {
+	var r = make(map[K][]K)
+	for _, a := range Nodes(g) {
+		var succ = []K{}
+		for n := range BFS(g, g[a]...) {
+			succ = append(succ, n)
+		}
+		slices.Sort(succ)
+		r[a] = succ
+	}
+	return r
+}
+
+// TransitiveReduction returns a new adjacency map with the fewest edges
+// preserving the reachability between nodes of `g`, by removing every edge
+// a -> b where `b` is also reachable from `a` through a longer path. Every
+// node of `g` is a key of the result, successors keep their original order,
+// and duplicate edges are dropped. The reduction is only unique for acyclic
+// graphs; TransitiveReduction returns a *CycleError if `g` contains a cycle.
+func TransitiveReduction[K constraints.Ordered](g map[K][]K) (map[K][]K, error) {
+	if _, err := TopologicalSort(g); err != nil {
+		return nil, err
+	}
+	var closure = TransitiveClosure(g)
+	var reach = make(map[K]map[K]bool, len(closure))
+	for a, bs := range closure {
+		reach[a] = make(map[K]bool, len(bs))
+		for _, b := range bs {
+			reach[a][b] = true
+		}
+	}
+
+	var r = make(map[K][]K, len(closure))
+	for a := range closure {
+		var succ = []K{}
+		for _, b := range g[a] {
+			if slices.Contains(succ, b) {
+				continue
+			}
+			var redundant = false
+			for _, c := range g[a] {
+				if c != b && reach[c][b] {
+					redundant = true
+					break
+				}
+			}
+			if !redundant {
+				succ = append(succ, b)
+			}
+		}
+		r[a] = succ
+	}
+	return r, nil
+}
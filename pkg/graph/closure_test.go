@@ -0,0 +1,47 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/graph"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestTransitiveClosure(t *testing.T) {
+	var g = map[int][]int{1: {2}, 2: {3}, 3: {2}, 4: nil}
+	require.That(t, graph.TransitiveClosure(g)).Eq(map[int][]int{
+		1: {2, 3},
+		2: {2, 3},
+		3: {2, 3},
+		4: {},
+	})
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	var g = map[string][]string{
+		"a": {"b", "c", "d", "e", "b"},
+		"b": {"d"},
+		"c": {"d", "e"},
+		"d": {"e"},
+	}
+	var r, err = graph.TransitiveReduction(g)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"e"},
+		"e": {},
+	})
+
+	for _, a := range graph.Nodes(g) {
+		for _, b := range graph.Nodes(g) {
+			require.That(t, graph.Reachable(r, a, b)).Eq(graph.Reachable(g, a, b))
+		}
+	}
+}
+
+func TestTransitiveReductionRejectsCycles(t *testing.T) {
+	var _, err = graph.TransitiveReduction(map[int][]int{1: {2}, 2: {1}})
+	require.That(t, err).IsError(graph.ErrCycle)
+}
@@ -0,0 +1,163 @@
+// Package graph provides algorithms over directed graphs represented as
+// adjacency maps, where `g[a]` lists the nodes `b` of each edge a -> b. Nodes
+// that only appear as edge targets are part of the graph. The `Graph` type
+// offers a small mutable wrapper around that representation.
+package graph
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Graph is a directed graph without parallel edges. The zero value is not
+// usable; use New() or FromMap() to create one.
+type Graph[K comparable] struct {
+	adj map[K][]K
+}
+
+// New creates a new empty graph.
+func New[K comparable]() *Graph[K] {
+	return &Graph[K]{adj: make(map[K][]K)}
+}
+
+// FromMap creates a new graph from the adjacency map `m`. The input is copied
+// and not retained, and duplicate edges are dropped.
+func FromMap[K comparable](m map[K][]K) *Graph[K] {
+	var g = New[K]()
+	for a, bs := range m {
+		g.AddNode(a)
+		for _, b := range bs {
+			g.AddEdge(a, b)
+		}
+	}
+	return g
+}
+
+// AddNode adds the node `k` to the graph, if not already present.
+func (g *Graph[K]) AddNode(k K) {
+	if _, ok := g.adj[k]; !ok {
+		g.adj[k] = nil
+	}
+}
+
+// AddEdge adds the edge a -> b to the graph, along with its nodes, and returns
+// true if the edge was not already present.
+func (g *Graph[K]) AddEdge(a, b K) bool {
+	g.AddNode(b)
+	if g.HasEdge(a, b) {
+		return false
+	}
+	g.adj[a] = append(g.adj[a], b)
+	return true
+}
+
+// HasEdge returns true if the graph contains the edge a -> b.
+func (g *Graph[K]) HasEdge(a, b K) bool {
+	return slices.Contains(g.adj[a], b)
+}
+
+// RemoveEdge removes the edge a -> b from the graph and returns true if it was
+// present. Nodes are not removed.
+func (g *Graph[K]) RemoveEdge(a, b K) bool {
+	var i = slices.Index(g.adj[a], b)
+	if i < 0 {
+		return false
+	}
+	g.adj[a] = slices.Delete(g.adj[a], i, i+1)
+	return true
+}
+
+// RemoveNode removes the node `k` and all its incoming and outgoing edges
+// from the graph, and returns true if it was present.
+func (g *Graph[K]) RemoveNode(k K) bool {
+	if _, ok := g.adj[k]; !ok {
+		return false
+	}
+	delete(g.adj, k)
+	for a := range g.adj {
+		g.RemoveEdge(a, k)
+	}
+	return true
+}
+
+// HasNode returns true if `k` is a node of the graph.
+func (g *Graph[K]) HasNode(k K) bool {
+	var _, ok = g.adj[k]
+	return ok
+}
+
+// Successors returns the targets of the edges starting at `k`, in insertion
+// order.
+func (g *Graph[K]) Successors(k K) []K {
+	return append([]K(nil), g.adj[k]...)
+}
+
+// Len returns the number of nodes in the graph.
+func (g *Graph[K]) Len() int {
+	return len(g.adj)
+}
+
+// Nodes returns the nodes of the graph, in no particular order.
+func (g *Graph[K]) Nodes() []K {
+	var r = make([]K, 0, len(g.adj))
+	for k := range g.adj {
+		r = append(r, k)
+	}
+	return r
+}
+
+// Reverse returns a new graph with all edges reversed.
+func (g *Graph[K]) Reverse() *Graph[K] {
+	var r = New[K]()
+	for a, bs := range g.adj {
+		r.AddNode(a)
+		for _, b := range bs {
+			r.AddEdge(b, a)
+		}
+	}
+	return r
+}
+
+// Map returns the adjacency map of the graph, suitable for the functions of
+// this package. Every node is a key of the map, with possibly no successors.
+// The result is a copy and can be modified freely.
+func (g *Graph[K]) Map() map[K][]K {
+	var r = make(map[K][]K, len(g.adj))
+	for a, bs := range g.adj {
+		r[a] = append([]K(nil), bs...)
+	}
+	return r
+}
+
+// Nodes returns all the nodes of `g`, including those only appearing as edge
+// targets, sorted in increasing order.
+func Nodes[K constraints.Ordered](g map[K][]K) []K {
+	var seen = make(map[K]bool, len(g))
+	var r = make([]K, 0, len(g))
+	var add = func(k K) {
+		if !seen[k] {
+			seen[k] = true
+			r = append(r, k)
+		}
+	}
+	for a, bs := range g {
+		add(a)
+		for _, b := range bs {
+			add(b)
+		}
+	}
+	slices.Sort(r)
+	return r
+}
+
+// Reverse returns a new adjacency map with all the edges of `g` reversed.
+// Nodes of `g` without any incoming edge are not keys of the result.
+func Reverse[K comparable](g map[K][]K) map[K][]K {
+	var r = make(map[K][]K, len(g))
+	for a, bs := range g {
+		for _, b := range bs {
+			r[b] = append(r[b], a)
+		}
+	}
+	return r
+}
@@ -0,0 +1,58 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/graph"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestGraph(t *testing.T) {
+	var g = graph.New[string]()
+	require.That(t, g.AddEdge("a", "b")).IsTrue()
+	require.That(t, g.AddEdge("a", "b")).IsFalse()
+	require.That(t, g.AddEdge("a", "c")).IsTrue()
+	require.That(t, g.AddEdge("b", "c")).IsTrue()
+	g.AddNode("d")
+
+	require.That(t, g.Len()).Eq(4)
+	require.That(t, g.Nodes()).IsEqualSet([]string{"a", "b", "c", "d"})
+	require.That(t, g.Successors("a")).Eq([]string{"b", "c"})
+	require.That(t, g.HasEdge("b", "c")).IsTrue()
+	require.That(t, g.HasEdge("c", "b")).IsFalse()
+	require.That(t, g.HasNode("d")).IsTrue()
+	require.That(t, g.Map()).Eq(map[string][]string{
+		"a": {"b", "c"}, "b": {"c"}, "c": nil, "d": nil,
+	})
+
+	require.That(t, g.RemoveEdge("a", "b")).IsTrue()
+	require.That(t, g.RemoveEdge("a", "b")).IsFalse()
+	require.That(t, g.RemoveNode("c")).IsTrue()
+	require.That(t, g.RemoveNode("c")).IsFalse()
+	require.That(t, g.Map()).Eq(map[string][]string{
+		"a": nil, "b": nil, "d": nil,
+	})
+}
+
+func TestGraphFromMapAndReverse(t *testing.T) {
+	var g = graph.FromMap(map[int][]int{1: {2, 3, 2}, 2: {3}})
+	require.That(t, g.Successors(1)).Eq([]int{2, 3})
+	require.That(t, g.Len()).Eq(3)
+
+	var r = g.Reverse()
+	require.That(t, r.Successors(3)).IsEqualSet([]int{1, 2})
+	require.That(t, r.Successors(1)).IsEmpty()
+	require.That(t, r.Len()).Eq(3)
+}
+
+func TestNodes(t *testing.T) {
+	var g = map[int][]int{3: {1, 4}, 1: {5}}
+	require.That(t, graph.Nodes(g)).Eq([]int{1, 3, 4, 5})
+}
+
+func TestReverse(t *testing.T) {
+	var g = map[int][]int{1: {2, 3}, 2: {3}}
+	var r = graph.Reverse(g)
+	require.That(t, r).MapKeys().IsEqualSet([]int{2, 3})
+	require.That(t, r[3]).IsEqualSet([]int{1, 2})
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"container/heap"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+)
+
+// ShortestPath returns a path from `a` to `b` with the smallest number of
+// edges, as the list of nodes along the path including both ends. The second
+// return value is false if `b` is not reachable from `a`.
+func ShortestPath[K comparable](g map[K][]K, a, b K) ([]K, bool) {
+	var parent = map[K]K{a: a}
+	var queue = []K{a}
+	for len(queue) > 0 {
+		var k = queue[0]
+		queue = queue[1:]
+		if k == b {
+			return walkBack(parent, a, b), true
+		}
+		for _, n := range g[k] {
+			if _, ok := parent[n]; !ok {
+				parent[n] = k
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil, false
+}
+
+// Dijkstra returns a path from `a` to `b` minimizing the sum of the weights
+// of its edges, as returned by `weight`, along with that total weight. The
+// last return value is false if `b` is not reachable from `a`. Weights must
+// not be negative.
+func Dijkstra[K comparable, W slices.Number](g map[K][]K, a, b K, weight func(from, to K) W) ([]K, W, bool) {
+	var dist = map[K]W{a: 0}
+	var parent = map[K]K{a: a}
+	var done = make(map[K]bool)
+	var queue = &distHeap[K, W]{{a, 0}}
+	for queue.Len() > 0 {
+		var item = heap.Pop(queue).(distItem[K, W])
+		var k = item.node
+		if done[k] {
+			continue
+		}
+		done[k] = true
+		if k == b {
+			return walkBack(parent, a, b), item.dist, true
+		}
+		for _, n := range g[k] {
+			var d = item.dist + weight(k, n)
+			if old, ok := dist[n]; !done[n] && (!ok || d < old) {
+				dist[n] = d
+				parent[n] = k
+				heap.Push(queue, distItem[K, W]{n, d})
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// walkBack reconstructs the path from `a` to `b` from the `parent` links.
+func walkBack[K comparable](parent map[K]K, a, b K) []K {
+	var r = []K{b}
+	for k := b; k != a; {
+		k = parent[k]
+		r = append(r, k)
+	}
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return r
+}
+
+type distItem[K comparable, W slices.Number] struct {
+	node K
+	dist W
+}
+
+// distHeap implements heap.Interface over nodes ordered by distance.
+type distHeap[K comparable, W slices.Number] []distItem[K, W]
+
+func (h distHeap[K, W]) Len() int           { return len(h) }
+func (h distHeap[K, W]) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h distHeap[K, W]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *distHeap[K, W]) Push(x any)        { *h = append(*h, x.(distItem[K, W])) }
+func (h *distHeap[K, W]) Pop() any {
+	var old = *h
+	var x = old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,48 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/graph"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestShortestPath(t *testing.T) {
+	var g = map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"e"},
+		"e": {"d"},
+	}
+	var p, ok = graph.ShortestPath(g, "a", "d")
+	require.That(t, ok).IsTrue()
+	require.That(t, p).Eq([]string{"a", "b", "d"})
+
+	p, _ = graph.ShortestPath(g, "a", "a")
+	require.That(t, p).Eq([]string{"a"})
+
+	_, ok = graph.ShortestPath(g, "d", "a")
+	require.That(t, ok).IsFalse()
+}
+
+func TestDijkstra(t *testing.T) {
+	var g = map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"e"},
+		"e": {"d"},
+	}
+	var weights = map[[2]string]float64{
+		{"a", "b"}: 1, {"b", "d"}: 10,
+		{"a", "c"}: 2, {"c", "e"}: 3, {"e", "d"}: 1,
+	}
+	var weight = func(a, b string) float64 { return weights[[2]string{a, b}] }
+
+	var p, w, ok = graph.Dijkstra(g, "a", "d", weight)
+	require.That(t, ok).IsTrue()
+	require.That(t, p).Eq([]string{"a", "c", "e", "d"})
+	require.That(t, w).Eq(6.0)
+
+	_, _, ok = graph.Dijkstra(g, "d", "a", weight)
+	require.That(t, ok).IsFalse()
+}
@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// ErrCycle is the error wrapped by CycleError.
+var ErrCycle = errors.New("graph: cycle detected")
+
+// CycleError is returned by functions requiring an acyclic graph, and reports
+// one of the cycles found. `Cycle` lists the nodes along the cycle, with the
+// first node repeated at the end.
+type CycleError[K comparable] struct {
+	Cycle []K
+}
+
+func (e *CycleError[K]) Error() string {
+	var s = make([]string, 0, len(e.Cycle))
+	for _, k := range e.Cycle {
+		s = append(s, fmt.Sprint(k))
+	}
+	return fmt.Sprintf("%v: %v", ErrCycle, strings.Join(s, " -> "))
+}
+
+// Unwrap returns ErrCycle, so that `errors.Is(err, ErrCycle)` matches any
+// CycleError.
+func (e *CycleError[K]) Unwrap() error {
+	return ErrCycle
+}
+
+// TopologicalSort returns the nodes of `g` ordered such that for every edge
+// a -> b, `a` comes before `b`. When several nodes are ready at the same time,
+// the smallest comes first, making the result deterministic. If `g` contains a
+// cycle, TopologicalSort returns a *CycleError reporting one of them. For a
+// dependency graph where `g[a]` lists the dependencies of `a`, dependencies
+// come last; reverse the graph first to get them first.
+func TopologicalSort[K constraints.Ordered](g map[K][]K) ([]K, error) {
+	var nodes = Nodes(g)
+	var indegree = make(map[K]int, len(nodes))
+	for _, bs := range g {
+		for _, b := range bs {
+			indegree[b]++
+		}
+	}
+
+	var ready = &minHeap[K]{}
+	for _, k := range nodes {
+		if indegree[k] == 0 {
+			heap.Push(ready, k)
+		}
+	}
+	var r = make([]K, 0, len(nodes))
+	for ready.Len() > 0 {
+		var k = heap.Pop(ready).(K)
+		r = append(r, k)
+		for _, n := range g[k] {
+			indegree[n]--
+			if indegree[n] == 0 {
+				heap.Push(ready, n)
+			}
+		}
+	}
+	if len(r) < len(nodes) {
+		return nil, &CycleError[K]{Cycle: findCycle(g, nodes, indegree)}
+	}
+	return r, nil
+}
+
+// findCycle returns a cycle among the nodes left with a positive in-degree
+// after a topological sort. Each of them has a predecessor also left, so
+// walking predecessors from any of them eventually loops.
+func findCycle[K constraints.Ordered](g map[K][]K, nodes []K, indegree map[K]int) []K {
+	var pred = make(map[K]K)
+	for _, a := range nodes {
+		if indegree[a] == 0 {
+			continue
+		}
+		for _, b := range g[a] {
+			if p, ok := pred[b]; indegree[b] > 0 && (!ok || a < p) {
+				pred[b] = a
+			}
+		}
+	}
+
+	var start K
+	for _, k := range nodes {
+		if indegree[k] > 0 {
+			start = k
+			break
+		}
+	}
+	var pos = make(map[K]int)
+	var walk []K
+	for k := start; ; k = pred[k] {
+		if i, ok := pos[k]; ok {
+			walk = walk[i:]
+			break
+		}
+		pos[k] = len(walk)
+		walk = append(walk, k)
+	}
+
+	// Reverse the walk into edge order, starting from the smallest node
+	var first = 0
+	for i, k := range walk {
+		if k < walk[first] {
+			first = i
+		}
+	}
+	var cycle = make([]K, 0, len(walk)+1)
+	for i := range walk {
+		cycle = append(cycle, walk[(first-i+len(walk))%len(walk)])
+	}
+	return append(cycle, cycle[0])
+}
+
+// StronglyConnectedComponents returns the strongly connected components of
+// `g`, the maximal sets of nodes that are all reachable from each other. The
+// components are returned in reverse topological order, each one sorted in
+// increasing order.
+func StronglyConnectedComponents[K constraints.Ordered](g map[K][]K) [][]K {
+	// Iterative version of Tarjan's algorithm
+	type frame struct {
+		node K
+		next int
+	}
+	var index = make(map[K]int)
+	var lowlink = make(map[K]int)
+	var onStack = make(map[K]bool)
+	var stack []K
+	var r [][]K
+
+	for _, root := range Nodes(g) {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		var frames = []frame{{node: root}}
+		index[root], lowlink[root] = len(index), len(index)
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(frames) > 0 {
+			var f = &frames[len(frames)-1]
+			if f.next < len(g[f.node]) {
+				var n = g[f.node][f.next]
+				f.next++
+				if _, ok := index[n]; !ok {
+					index[n], lowlink[n] = len(index), len(index)
+					stack = append(stack, n)
+					onStack[n] = true
+					frames = append(frames, frame{node: n})
+				} else if onStack[n] {
+					lowlink[f.node] = min(lowlink[f.node], index[n])
+				}
+				continue
+			}
+
+			var k = f.node
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				var p = frames[len(frames)-1].node
+				lowlink[p] = min(lowlink[p], lowlink[k])
+			}
+			if lowlink[k] == index[k] {
+				var i = len(stack) - 1
+				for stack[i] != k {
+					i--
+				}
+				var c = append([]K(nil), stack[i:]...)
+				for _, n := range c {
+					onStack[n] = false
+				}
+				stack = stack[:i]
+				slices.Sort(c)
+				r = append(r, c)
+			}
+		}
+	}
+	return r
+}
+
+// minHeap implements heap.Interface over ordered values.
+type minHeap[K constraints.Ordered] []K
+
+func (h minHeap[K]) Len() int           { return len(h) }
+func (h minHeap[K]) Less(i, j int) bool { return h[i] < h[j] }
+func (h minHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap[K]) Push(x any)        { *h = append(*h, x.(K)) }
+func (h *minHeap[K]) Pop() any {
+	var old = *h
+	var x = old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,86 @@
+package graph_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/graph"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	var g = map[string][]string{
+		"shirt":  {"tie", "belt"},
+		"tie":    {"jacket"},
+		"pants":  {"shoes", "belt"},
+		"belt":   {"jacket"},
+		"socks":  {"shoes"},
+		"watch":  nil,
+		"jacket": nil,
+	}
+	var r, err = graph.TopologicalSort(g)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq([]string{
+		"pants", "shirt", "belt", "socks", "shoes", "tie", "jacket", "watch",
+	})
+}
+
+func TestTopologicalSortIsDeterministic(t *testing.T) {
+	var g = map[int][]int{5: {1}, 4: {1}, 3: {1}, 2: {1}}
+	for i := 0; i < 20; i++ {
+		var r, _ = graph.TopologicalSort(g)
+		require.That(t, r).Eq([]int{2, 3, 4, 5, 1})
+	}
+}
+
+func TestTopologicalSortReportsCycle(t *testing.T) {
+	var g = map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"d", "e"},
+		"d": {"b"},
+		"e": nil,
+	}
+	var r, err = graph.TopologicalSort(g)
+	require.That(t, r).IsNil()
+	require.That(t, err).IsError(graph.ErrCycle)
+	require.That(t, err).ToString().Eq("graph: cycle detected: b -> c -> d -> b")
+
+	var cycleErr *graph.CycleError[string]
+	require.That(t, errors.As(err, &cycleErr)).IsTrue()
+	require.That(t, cycleErr.Cycle).Eq([]string{"b", "c", "d", "b"})
+}
+
+func TestTopologicalSortSelfLoop(t *testing.T) {
+	var _, err = graph.TopologicalSort(map[int][]int{1: {2}, 2: {2}})
+	var cycleErr *graph.CycleError[int]
+	require.That(t, errors.As(err, &cycleErr)).IsTrue()
+	require.That(t, cycleErr.Cycle).Eq([]int{2, 2})
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	var g = map[int][]int{
+		1: {2},
+		2: {3},
+		3: {1, 4},
+		4: {5},
+		5: {6},
+		6: {4},
+		7: {6, 8},
+		8: {7},
+	}
+	require.That(t, graph.StronglyConnectedComponents(g)).Eq([][]int{
+		{4, 5, 6}, {1, 2, 3}, {7, 8},
+	})
+}
+
+func TestStronglyConnectedComponentsDeepGraph(t *testing.T) {
+	var chain = make(map[int][]int)
+	for i := 0; i < 100000; i++ {
+		chain[i] = []int{i + 1}
+	}
+	chain[100000] = []int{0}
+	var r = graph.StronglyConnectedComponents(chain)
+	require.That(t, r).Length().Eq(1)
+	require.That(t, r[0]).Length().Eq(100001)
+}
@@ -0,0 +1,84 @@
+package graph
+
+import "iter"
+
+// BFS returns an iterator over the nodes reachable from `start`, in
+// breadth-first order. Successors are visited in the order of the adjacency
+// lists, and each node is visited once.
+func BFS[K comparable](g map[K][]K, start ...K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		var seen = make(map[K]bool)
+		var queue []K
+		for _, s := range start {
+			if !seen[s] {
+				seen[s] = true
+				queue = append(queue, s)
+			}
+		}
+		for len(queue) > 0 {
+			var k = queue[0]
+			queue = queue[1:]
+			if !yield(k) {
+				return
+			}
+			for _, n := range g[k] {
+				if !seen[n] {
+					seen[n] = true
+					queue = append(queue, n)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the nodes reachable from `start`, in
+// depth-first pre-order. Successors are visited in the order of the adjacency
+// lists, and each node is visited once. The traversal is iterative and
+// supports arbitrarily deep graphs.
+func DFS[K comparable](g map[K][]K, start ...K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		var seen = make(map[K]bool)
+		var stack []K
+		for i := len(start) - 1; i >= 0; i-- {
+			stack = append(stack, start[i])
+		}
+		for len(stack) > 0 {
+			var k = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if !yield(k) {
+				return
+			}
+			var next = g[k]
+			for i := len(next) - 1; i >= 0; i-- {
+				if !seen[next[i]] {
+					stack = append(stack, next[i])
+				}
+			}
+		}
+	}
+}
+
+// Reachable returns true if there is a path from `a` to `b` in `g`. A node is
+// always reachable from itself.
+func Reachable[K comparable](g map[K][]K, a, b K) bool {
+	for k := range BFS(g, a) {
+		if k == b {
+			return true
+		}
+	}
+	return false
+}
+
+// ReachableFrom returns the set of nodes reachable from any of the `start`
+// nodes, including the start nodes themselves.
+func ReachableFrom[K comparable](g map[K][]K, start ...K) map[K]bool {
+	var r = make(map[K]bool)
+	for k := range BFS(g, start...) {
+		r[k] = true
+	}
+	return r
+}
@@ -0,0 +1,64 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/graph"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+var tree = map[int][]int{
+	1: {2, 3},
+	2: {4, 5},
+	3: {6},
+	5: {1}, // back edge
+}
+
+func collect[K any](seq func(yield func(K) bool)) []K {
+	var r []K
+	for k := range seq {
+		r = append(r, k)
+	}
+	return r
+}
+
+func TestBFS(t *testing.T) {
+	require.That(t, collect(graph.BFS(tree, 1))).Eq([]int{1, 2, 3, 4, 5, 6})
+	require.That(t, collect(graph.BFS(tree, 3, 2))).Eq([]int{3, 2, 6, 4, 5, 1})
+	require.That(t, collect(graph.BFS(tree, 7))).Eq([]int{7})
+}
+
+func TestDFS(t *testing.T) {
+	require.That(t, collect(graph.DFS(tree, 1))).Eq([]int{1, 2, 4, 5, 3, 6})
+	require.That(t, collect(graph.DFS(tree, 3, 2))).Eq([]int{3, 6, 2, 4, 5, 1})
+}
+
+func TestTraversalStopsEarly(t *testing.T) {
+	var r []int
+	for k := range graph.DFS(tree, 1) {
+		if k == 5 {
+			break
+		}
+		r = append(r, k)
+	}
+	require.That(t, r).Eq([]int{1, 2, 4})
+}
+
+func TestDFSDeepGraph(t *testing.T) {
+	var chain = make(map[int][]int)
+	for i := 0; i < 100000; i++ {
+		chain[i] = []int{i + 1}
+	}
+	var n = 0
+	for range graph.DFS(chain, 0) {
+		n++
+	}
+	require.That(t, n).Eq(100001)
+}
+
+func TestReachable(t *testing.T) {
+	require.That(t, graph.Reachable(tree, 5, 6)).IsTrue()
+	require.That(t, graph.Reachable(tree, 6, 5)).IsFalse()
+	require.That(t, graph.Reachable(tree, 6, 6)).IsTrue()
+	require.That(t, graph.ReachableFrom(tree, 3)).Eq(map[int]bool{3: true, 6: true})
+}
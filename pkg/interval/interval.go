@@ -0,0 +1,127 @@
+// Package interval provides half-open intervals over ordered types, set
+// operations on slices of intervals, and an interval tree for efficient point
+// and overlap queries.
+package interval
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Interval represents the half-open interval [Start, End), containing all
+// values greater than or equal to Start and strictly less than End. An
+// interval where End is not greater than Start is empty.
+type Interval[T constraints.Ordered] struct {
+	Start T
+	End   T
+}
+
+// New returns the interval [`start`, `end`).
+func New[T constraints.Ordered](start, end T) Interval[T] {
+	return Interval[T]{Start: start, End: end}
+}
+
+// IsEmpty returns true if the interval contains no value.
+func (i Interval[T]) IsEmpty() bool {
+	return !(i.Start < i.End)
+}
+
+// Contains returns true if `x` is in the interval.
+func (i Interval[T]) Contains(x T) bool {
+	return i.Start <= x && x < i.End
+}
+
+// Overlaps returns true if the two intervals have at least one value in
+// common. Intervals that only touch, like [1, 2) and [2, 3), do not overlap,
+// and empty intervals overlap nothing.
+func (i Interval[T]) Overlaps(o Interval[T]) bool {
+	return i.Start < o.End && o.Start < i.End && !i.IsEmpty() && !o.IsEmpty()
+}
+
+// Intersection returns the interval of values common to both intervals. The
+// second return value is false if the intersection is empty.
+func (i Interval[T]) Intersection(o Interval[T]) (Interval[T], bool) {
+	var r = Interval[T]{Start: max(i.Start, o.Start), End: min(i.End, o.End)}
+	return r, !r.IsEmpty()
+}
+
+// MergeOverlapping returns the smallest set of disjoint intervals covering the
+// same values as `v`, sorted by increasing start. Overlapping and adjacent
+// intervals are merged, and empty intervals are dropped. The result is the
+// normalized form expected by the other set operations.
+func MergeOverlapping[T constraints.Ordered](v []Interval[T]) []Interval[T] {
+	var r = make([]Interval[T], 0, len(v))
+	for _, i := range v {
+		if !i.IsEmpty() {
+			r = append(r, i)
+		}
+	}
+	slices.SortFunc(r, func(a, b Interval[T]) bool {
+		return a.Start < b.Start
+	})
+
+	var n = 0
+	for _, i := range r {
+		if n > 0 && i.Start <= r[n-1].End {
+			r[n-1].End = max(r[n-1].End, i.End)
+			continue
+		}
+		r[n] = i
+		n++
+	}
+	return r[:n]
+}
+
+// Union returns the normalized set of intervals covering all values in
+// either `a` or `b`.
+func Union[T constraints.Ordered](a, b []Interval[T]) []Interval[T] {
+	return MergeOverlapping(append(append([]Interval[T](nil), a...), b...))
+}
+
+// Intersect returns the normalized set of intervals covering the values that
+// are both in `a` and in `b`.
+func Intersect[T constraints.Ordered](a, b []Interval[T]) []Interval[T] {
+	a, b = MergeOverlapping(a), MergeOverlapping(b)
+	var r []Interval[T]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if x, ok := a[i].Intersection(b[j]); ok {
+			r = append(r, x)
+		}
+		if a[i].End < b[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return r
+}
+
+// Subtract returns the normalized set of intervals covering the values that
+// are in `a` but not in `b`.
+func Subtract[T constraints.Ordered](a, b []Interval[T]) []Interval[T] {
+	a, b = MergeOverlapping(a), MergeOverlapping(b)
+	var r []Interval[T]
+	var j = 0
+	for _, i := range a {
+		for j < len(b) && b[j].End <= i.Start {
+			j++
+		}
+		var start = i.Start
+		for k := j; k < len(b) && b[k].Start < i.End; k++ {
+			if start < b[k].Start {
+				r = append(r, Interval[T]{Start: start, End: b[k].Start})
+			}
+			start = max(start, b[k].End)
+		}
+		if start < i.End {
+			r = append(r, Interval[T]{Start: start, End: i.End})
+		}
+	}
+	return r
+}
+
+// Gaps returns the normalized set of intervals within `bound` that are not
+// covered by any interval of `v`.
+func Gaps[T constraints.Ordered](v []Interval[T], bound Interval[T]) []Interval[T] {
+	return Subtract([]Interval[T]{bound}, v)
+}
@@ -0,0 +1,97 @@
+package interval_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/interval"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+type I = interval.Interval[int]
+
+func TestIntervalContains(t *testing.T) {
+	var i = interval.New(1, 3)
+	require.That(t, i.Contains(0)).IsFalse()
+	require.That(t, i.Contains(1)).IsTrue()
+	require.That(t, i.Contains(2)).IsTrue()
+	require.That(t, i.Contains(3)).IsFalse()
+	require.That(t, interval.New(2, 2).Contains(2)).IsFalse()
+}
+
+func TestIntervalIsEmpty(t *testing.T) {
+	require.That(t, interval.New(1, 2).IsEmpty()).IsFalse()
+	require.That(t, interval.New(2, 2).IsEmpty()).IsTrue()
+	require.That(t, interval.New(3, 2).IsEmpty()).IsTrue()
+}
+
+func TestIntervalOverlaps(t *testing.T) {
+	var i = interval.New(1, 3)
+	require.That(t, i.Overlaps(I{2, 5})).IsTrue()
+	require.That(t, i.Overlaps(I{0, 2})).IsTrue()
+	require.That(t, i.Overlaps(I{3, 5})).IsFalse()
+	require.That(t, i.Overlaps(I{0, 1})).IsFalse()
+	require.That(t, i.Overlaps(I{2, 2})).IsFalse()
+}
+
+func TestIntervalIntersection(t *testing.T) {
+	var r, ok = interval.New(1, 5).Intersection(I{3, 8})
+	require.That(t, r).Eq(I{3, 5})
+	require.That(t, ok).IsTrue()
+
+	_, ok = interval.New(1, 3).Intersection(I{3, 8})
+	require.That(t, ok).IsFalse()
+}
+
+func TestMergeOverlapping(t *testing.T) {
+	var v = []I{{8, 10}, {1, 3}, {2, 4}, {4, 5}, {6, 6}, {7, 8}, {12, 11}}
+	require.That(t, interval.MergeOverlapping(v)).Eq([]I{{1, 5}, {7, 10}})
+	require.That(t, v[0]).Eq(I{8, 10})
+	require.That(t, interval.MergeOverlapping([]I{})).IsEmpty()
+}
+
+func TestUnion(t *testing.T) {
+	var a = []I{{1, 3}, {10, 12}}
+	var b = []I{{3, 5}, {11, 15}, {20, 21}}
+	require.That(t, interval.Union(a, b)).Eq([]I{{1, 5}, {10, 15}, {20, 21}})
+}
+
+func TestIntersect(t *testing.T) {
+	var a = []I{{0, 5}, {10, 20}}
+	var b = []I{{3, 12}, {15, 16}, {18, 25}, {5, 6}}
+	require.That(t, interval.Intersect(a, b)).Eq([]I{{3, 5}, {10, 12}, {15, 16}, {18, 20}})
+	require.That(t, interval.Intersect(a, []I{{5, 10}})).IsEmpty()
+}
+
+func TestSubtract(t *testing.T) {
+	var a = []I{{0, 10}, {20, 30}}
+	var b = []I{{2, 4}, {8, 22}, {25, 26}, {30, 40}}
+	require.That(t, interval.Subtract(a, b)).Eq([]I{{0, 2}, {4, 8}, {22, 25}, {26, 30}})
+	require.That(t, interval.Subtract(a, nil)).Eq(a)
+	require.That(t, interval.Subtract(a, []I{{-5, 50}})).IsEmpty()
+}
+
+func TestGaps(t *testing.T) {
+	var busy = []I{{9, 10}, {13, 14}, {10, 12}}
+	require.That(t, interval.Gaps(busy, I{8, 18})).Eq([]I{{8, 9}, {12, 13}, {14, 18}})
+	require.That(t, interval.Gaps(busy, I{9, 12})).IsEmpty()
+	require.That(t, interval.Gaps(nil, I{9, 12})).Eq([]I{{9, 12}})
+}
+
+func TestSetOperationsMatchPointwise(t *testing.T) {
+	var a = []I{{0, 7}, {3, 9}, {12, 15}, {20, 21}, {22, 30}}
+	var b = []I{{5, 13}, {14, 16}, {21, 22}, {25, 26}}
+	var in = func(v []I, x int) bool {
+		for _, i := range v {
+			if i.Contains(x) {
+				return true
+			}
+		}
+		return false
+	}
+	var union, inter, diff = interval.Union(a, b), interval.Intersect(a, b), interval.Subtract(a, b)
+	for x := -2; x < 35; x++ {
+		require.That(t, in(union, x)).Eq(in(a, x) || in(b, x))
+		require.That(t, in(inter, x)).Eq(in(a, x) && in(b, x))
+		require.That(t, in(diff, x)).Eq(in(a, x) && !in(b, x))
+	}
+}
@@ -0,0 +1,113 @@
+package interval
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Tree is an immutable interval tree, indexing elements by an interval to
+// efficiently find the elements containing a given value or overlapping a
+// given interval. It is stored as an implicit balanced binary search tree over
+// the elements sorted by interval start, augmented with the maximum end of
+// each subtree.
+type Tree[T constraints.Ordered, V any] struct {
+	items  []treeItem[T, V]
+	maxEnd []T
+}
+
+type treeItem[T constraints.Ordered, V any] struct {
+	interval Interval[T]
+	value    V
+}
+
+// NewTree builds an interval tree indexing each element of `v` by the interval
+// returned by `interval`, in O(n log n). Elements with an empty interval are
+// never returned by queries.
+func NewTree[T constraints.Ordered, V any](v []V, interval func(v V) Interval[T]) *Tree[T, V] {
+	var t = &Tree[T, V]{
+		items:  make([]treeItem[T, V], 0, len(v)),
+		maxEnd: make([]T, len(v)),
+	}
+	for _, vv := range v {
+		t.items = append(t.items, treeItem[T, V]{interval(vv), vv})
+	}
+	slices.SortStableFunc(t.items, func(a, b treeItem[T, V]) bool {
+		return a.interval.Start < b.interval.Start
+	})
+	t.build(0, len(t.items))
+	return t
+}
+
+// build computes the maximum end of the subtree spanning items [lo, hi) and
+// rooted at the middle item.
+func (t *Tree[T, V]) build(lo, hi int) (T, bool) {
+	var zero T
+	if lo >= hi {
+		return zero, false
+	}
+	var mid = int(uint(lo+hi) >> 1)
+	var m = t.items[mid].interval.End
+	if l, ok := t.build(lo, mid); ok {
+		m = max(m, l)
+	}
+	if r, ok := t.build(mid+1, hi); ok {
+		m = max(m, r)
+	}
+	t.maxEnd[mid] = m
+	return m, true
+}
+
+// Len returns the number of elements in the tree.
+func (t *Tree[T, V]) Len() int {
+	return len(t.items)
+}
+
+// Stab returns the elements whose interval contains `x`, sorted by increasing
+// interval start.
+func (t *Tree[T, V]) Stab(x T) []V {
+	var r []V
+	t.query(0, len(t.items), x,
+		func(start T) bool { return start > x },
+		func(i Interval[T]) bool { return i.Contains(x) },
+		func(v V) { r = append(r, v) })
+	return r
+}
+
+// Overlapping returns the elements whose interval overlaps `q`, sorted by
+// increasing interval start.
+func (t *Tree[T, V]) Overlapping(q Interval[T]) []V {
+	var r []V
+	if q.IsEmpty() {
+		return r
+	}
+	t.query(0, len(t.items), q.Start,
+		func(start T) bool { return start >= q.End },
+		q.Overlaps,
+		func(v V) { r = append(r, v) })
+	return r
+}
+
+// query visits in order the subtree spanning items [lo, hi), and invokes `f`
+// with each element whose interval satisfies `match`. Subtrees are skipped
+// when all their intervals end at or before `from`, or when all their
+// intervals start beyond the query, as reported by `beyond`.
+func (t *Tree[T, V]) query(lo, hi int, from T, beyond func(start T) bool,
+	match func(i Interval[T]) bool, f func(v V)) {
+
+	if lo >= hi {
+		return
+	}
+	var mid = int(uint(lo+hi) >> 1)
+	if t.maxEnd[mid] <= from {
+		return
+	}
+	t.query(lo, mid, from, beyond, match, f)
+	var item = &t.items[mid]
+	if beyond(item.interval.Start) {
+		return
+	}
+	if match(item.interval) {
+		f(item.value)
+	}
+	t.query(mid+1, hi, from, beyond, match, f)
+}
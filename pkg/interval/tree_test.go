@@ -0,0 +1,67 @@
+package interval_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/interval"
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+type booking struct {
+	name string
+	slot interval.Interval[int]
+}
+
+func bookingSlot(b booking) interval.Interval[int] {
+	return b.slot
+}
+
+func TestTreeStab(t *testing.T) {
+	var tree = interval.NewTree([]booking{
+		{"c", I{12, 14}},
+		{"a", I{9, 11}},
+		{"b", I{10, 12}},
+		{"empty", I{10, 10}},
+	}, bookingSlot)
+	var names = func(v []booking) []string {
+		return slices.Map(v, func(b booking) string { return b.name })
+	}
+
+	require.That(t, tree.Len()).Eq(4)
+	require.That(t, names(tree.Stab(8))).IsEmpty()
+	require.That(t, names(tree.Stab(9))).Eq([]string{"a"})
+	require.That(t, names(tree.Stab(10))).Eq([]string{"a", "b"})
+	require.That(t, names(tree.Stab(11))).Eq([]string{"b"})
+	require.That(t, names(tree.Stab(12))).Eq([]string{"c"})
+	require.That(t, names(tree.Stab(14))).IsEmpty()
+}
+
+func TestTreeOverlapping(t *testing.T) {
+	var identity = func(i I) I { return i }
+	var tree = interval.NewTree([]I{{1, 3}, {3, 5}, {5, 7}, {0, 10}}, identity)
+	require.That(t, tree.Overlapping(I{3, 5})).Eq([]I{{0, 10}, {3, 5}})
+	require.That(t, tree.Overlapping(I{2, 4})).Eq([]I{{0, 10}, {1, 3}, {3, 5}})
+	require.That(t, tree.Overlapping(I{10, 12})).IsEmpty()
+	require.That(t, tree.Overlapping(I{4, 4})).IsEmpty()
+}
+
+func TestTreeMatchesLinearScan(t *testing.T) {
+	var r = rand.New(rand.NewPCG(1, 2))
+	var v = slices.Generate(500, func(int) I {
+		var s = r.IntN(1000)
+		return I{s, s + r.IntN(50)}
+	})
+	var tree = interval.NewTree(v, func(i I) I { return i })
+	var sorted = slices.StableSortBy(v, func(i I) int { return i.Start })
+
+	for x := -5; x < 1060; x += 3 {
+		var expected = slices.Filter(sorted, func(i I) bool { return i.Contains(x) })
+		require.That(t, tree.Stab(x)).Eq(expected)
+
+		var q = I{x, x + 20}
+		expected = slices.Filter(sorted, func(i I) bool { return i.Overlaps(q) })
+		require.That(t, tree.Overlapping(q)).Eq(expected)
+	}
+}
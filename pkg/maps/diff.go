@@ -0,0 +1,102 @@
+package maps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change records the old and new values of a key present in both maps
+// compared by Diff().
+type Change[V any] struct {
+	Old V
+	New V
+}
+
+// MapDiff describes the differences between two maps, as returned by Diff().
+type MapDiff[K comparable, V any] struct {
+	Added   map[K]V
+	Removed map[K]V
+	Changed map[K]Change[V]
+}
+
+// Diff compares `old` and `new` and returns the keys that were added, removed
+// and changed between them.
+func Diff[K comparable, V comparable](old, new map[K]V) MapDiff[K, V] {
+	return DiffFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// DiffFunc is a variant of Diff() where values are compared with `eq`, for
+// values that are not comparable or need a custom notion of equality.
+func DiffFunc[K comparable, V any](old, new map[K]V, eq func(a, b V) bool) MapDiff[K, V] {
+	var d = MapDiff[K, V]{
+		Added:   make(map[K]V),
+		Removed: make(map[K]V),
+		Changed: make(map[K]Change[V]),
+	}
+	for k, o := range old {
+		if n, ok := new[k]; !ok {
+			d.Removed[k] = o
+		} else if !eq(o, n) {
+			d.Changed[k] = Change[V]{Old: o, New: n}
+		}
+	}
+	for k, n := range new {
+		if _, ok := old[k]; !ok {
+			d.Added[k] = n
+		}
+	}
+	return d
+}
+
+// IsEmpty returns true if the diff does not contain any change.
+func (d MapDiff[K, V]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String renders the diff with one line per key, sorted by the string
+// representation of the keys, so that the output is deterministic. Added,
+// removed and changed keys are prefixed respectively by `+`, `-` and `~`.
+func (d MapDiff[K, V]) String() string {
+	type line struct{ key, text string }
+	var lines []line
+	for k, v := range d.Added {
+		lines = append(lines, line{fmt.Sprint(k), fmt.Sprintf("+ %v: %v", k, v)})
+	}
+	for k, v := range d.Removed {
+		lines = append(lines, line{fmt.Sprint(k), fmt.Sprintf("- %v: %v", k, v)})
+	}
+	for k, c := range d.Changed {
+		lines = append(lines, line{fmt.Sprint(k), fmt.Sprintf("~ %v: %v -> %v", k, c.Old, c.New)})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].key < lines[j].key
+	})
+
+	var sb strings.Builder
+	for _, l := range lines {
+		sb.WriteString(l.text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ApplyDiff returns a copy of `m` updated with the changes recorded in `d`:
+// added and changed keys are set to their new value, and removed keys are
+// deleted. The original map is not modified.
+func ApplyDiff[K comparable, V any](m map[K]V, d MapDiff[K, V]) map[K]V {
+	var r = make(map[K]V, len(m)+len(d.Added))
+	for k, v := range m {
+		r[k] = v
+	}
+	for k := range d.Removed {
+		delete(r, k)
+	}
+	for k, v := range d.Added {
+		r[k] = v
+	}
+	for k, c := range d.Changed {
+		r[k] = c.New
+	}
+	return r
+}
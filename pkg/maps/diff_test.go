@@ -0,0 +1,50 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/maps"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestDiff(t *testing.T) {
+	var old = map[string]int{"a": 1, "b": 2, "c": 3}
+	var new = map[string]int{"a": 1, "b": 20, "d": 4}
+	var d = maps.Diff(old, new)
+	require.That(t, d.Added).Eq(map[string]int{"d": 4})
+	require.That(t, d.Removed).Eq(map[string]int{"c": 3})
+	require.That(t, d.Changed).Eq(map[string]maps.Change[int]{"b": {Old: 2, New: 20}})
+	require.That(t, d.IsEmpty()).IsFalse()
+	require.That(t, maps.ApplyDiff(old, d)).Eq(new)
+	require.That(t, old).Eq(map[string]int{"a": 1, "b": 2, "c": 3})
+}
+
+func TestDiffEqual(t *testing.T) {
+	var m = map[string]int{"a": 1}
+	var d = maps.Diff(m, m)
+	require.That(t, d.IsEmpty()).IsTrue()
+	require.That(t, d.String()).Eq("")
+}
+
+func TestDiffFunc(t *testing.T) {
+	var old = map[string][]int{"a": {1, 2}, "b": {3}}
+	var new = map[string][]int{"a": {1, 2}, "b": {3, 4}}
+	var d = maps.DiffFunc(old, new, func(a, b []int) bool {
+		return len(a) == len(b)
+	})
+	require.That(t, d.Added).IsEmpty()
+	require.That(t, d.Removed).IsEmpty()
+	require.That(t, d.Changed).MapKeys().Eq([]string{"b"})
+	require.That(t, maps.ApplyDiff(old, d)).Eq(new)
+}
+
+func TestDiffString(t *testing.T) {
+	var old = map[string]int{"b": 2, "c": 3, "e": 5}
+	var new = map[string]int{"a": 1, "b": 20, "e": 5}
+	for i := 0; i < 20; i++ {
+		require.That(t, maps.Diff(old, new).String()).Eq(
+			"+ a: 1\n" +
+				"~ b: 2 -> 20\n" +
+				"- c: 3\n")
+	}
+}
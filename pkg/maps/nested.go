@@ -0,0 +1,324 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The functions below operate on nested `map[string]any` trees, as obtained
+// for example by decoding JSON or YAML documents. Nested maps must be of type
+// `map[string]any` and nested lists of type `[]any` to be traversed.
+
+// ---------------------------------------------------------------------------
+// DeepMerge
+
+// MergeStrategy defines how DeepMerge() combines two values found at the same
+// path.
+type MergeStrategy int
+
+const (
+	// MergeDefault recurses into maps and replaces any other value.
+	MergeDefault MergeStrategy = iota
+
+	// MergeReplace replaces the destination value with the source value.
+	MergeReplace
+
+	// MergeAppend appends the source list to the destination list; it only
+	// applies to lists.
+	MergeAppend
+
+	// MergeRecurse merges the source map into the destination map; it only
+	// applies to maps.
+	MergeRecurse
+)
+
+// MergeOptions selects the merge strategy of DeepMerge() for each type of
+// value. The zero value recurses into maps and replaces lists. A strategy that
+// does not apply to its type of value, like MergeAppend for maps or
+// MergeRecurse for lists, behaves like MergeDefault. Strategies only apply when
+// both the source and destination values are of that type; otherwise the
+// source value always replaces the destination value.
+type MergeOptions struct {
+	Maps  MergeStrategy
+	Lists MergeStrategy
+}
+
+// DeepMerge returns a new tree containing the values of `dst` updated with the
+// values of `src`, according to the strategies selected in `opts`. Neither
+// input is modified, and the result does not share any nested map or list
+// with the inputs.
+func DeepMerge(dst, src map[string]any, opts MergeOptions) map[string]any {
+	var r = deepCopy(dst).(map[string]any)
+	if r == nil {
+		r = make(map[string]any)
+	}
+	for k, v := range src {
+		r[k] = mergeValue(r[k], deepCopy(v), opts)
+	}
+	return r
+}
+
+func mergeValue(dst, src any, opts MergeOptions) any {
+	switch s := src.(type) {
+	case map[string]any:
+		if d, ok := dst.(map[string]any); ok && opts.Maps != MergeReplace {
+			for k, v := range s {
+				d[k] = mergeValue(d[k], v, opts)
+			}
+			return d
+		}
+	case []any:
+		if d, ok := dst.([]any); ok && opts.Lists == MergeAppend {
+			return append(d, s...)
+		}
+	}
+	return src
+}
+
+func deepCopy(v any) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		if vv == nil {
+			return vv
+		}
+		var r = make(map[string]any, len(vv))
+		for k, a := range vv {
+			r[k] = deepCopy(a)
+		}
+		return r
+	case []any:
+		if vv == nil {
+			return vv
+		}
+		var r = make([]any, len(vv))
+		for i, a := range vv {
+			r[i] = deepCopy(a)
+		}
+		return r
+	}
+	return v
+}
+
+// DeepMerge
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Paths
+
+// ErrInvalidPath is returned when a path cannot be resolved or created
+// because an intermediate value is not a map.
+var ErrInvalidPath = errors.New("maps: invalid path")
+
+// GetPath returns the value found at the dot-separated `path` in `m`. The
+// second return value is false if the path does not exist.
+func GetPath(m map[string]any, path string) (any, bool) {
+	return GetPathKeys(m, strings.Split(path, ".")...)
+}
+
+// GetPathKeys returns the value found by following `keys` in `m`. Numeric
+// keys are used as indices to traverse lists. The second return value is false
+// if the path does not exist.
+func GetPathKeys(m map[string]any, keys ...string) (any, bool) {
+	var v any = m
+	for _, k := range keys {
+		switch vv := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = vv[k]; !ok {
+				return nil, false
+			}
+		case []any:
+			var i, err = strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(vv) {
+				return nil, false
+			}
+			v = vv[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// SetPath sets the value at the dot-separated `path` in `m`, creating
+// intermediate maps as needed. It fails with ErrInvalidPath if an intermediate
+// value exists and is not a map.
+func SetPath(m map[string]any, path string, v any) error {
+	return SetPathKeys(m, strings.Split(path, "."), v)
+}
+
+// SetPathKeys sets the value found by following `keys` in `m`, creating
+// intermediate maps as needed. It fails with ErrInvalidPath if an intermediate
+// value exists and is not a map.
+func SetPathKeys(m map[string]any, keys []string, v any) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: empty path", ErrInvalidPath)
+	}
+	for i, k := range keys[:len(keys)-1] {
+		var next, ok = m[k]
+		if !ok {
+			var n = make(map[string]any)
+			m[k] = n
+			m = n
+			continue
+		}
+		if m, ok = next.(map[string]any); !ok {
+			return fmt.Errorf("%w: '%v' is not a map",
+				ErrInvalidPath, strings.Join(keys[:i+1], "."))
+		}
+	}
+	m[keys[len(keys)-1]] = v
+	return nil
+}
+
+// DeletePath removes the value at the dot-separated `path` in `m`, and returns
+// true if a value was removed.
+func DeletePath(m map[string]any, path string) bool {
+	return DeletePathKeys(m, strings.Split(path, ".")...)
+}
+
+// DeletePathKeys removes the value found by following `keys` in `m`, and
+// returns true if a value was removed.
+func DeletePathKeys(m map[string]any, keys ...string) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	var parent, ok = GetPathKeys(m, keys[:len(keys)-1]...)
+	if !ok {
+		return false
+	}
+	var pm map[string]any
+	if pm, ok = parent.(map[string]any); !ok {
+		return false
+	}
+	var k = keys[len(keys)-1]
+	if _, ok = pm[k]; !ok {
+		return false
+	}
+	delete(pm, k)
+	return true
+}
+
+// Paths
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// Flatten
+
+// Flatten returns a single-level map where each leaf value of `m` is stored
+// under the keys of its path joined with `sep`. Lists are considered leaf
+// values, and empty nested maps are preserved as values.
+func Flatten(m map[string]any, sep string) map[string]any {
+	var r = make(map[string]any)
+	flatten(r, "", m, sep)
+	return r
+}
+
+func flatten(r map[string]any, prefix string, m map[string]any, sep string) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + sep + k
+		}
+		if vv, ok := v.(map[string]any); ok && len(vv) > 0 {
+			flatten(r, k, vv, sep)
+		} else {
+			r[k] = v
+		}
+	}
+}
+
+// Unflatten is the inverse of Flatten() and returns a nested tree where each
+// key of `m` is split on `sep` to form the path of its value. It fails with
+// ErrInvalidPath if a key is both a leaf value and the prefix of another key.
+func Unflatten(m map[string]any, sep string) (map[string]any, error) {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	// Processing keys in sorted order makes conflict reporting deterministic.
+	sort.Strings(keys)
+
+	var r = make(map[string]any)
+	for _, k := range keys {
+		var path = strings.Split(k, sep)
+		if existing, ok := GetPathKeys(r, path...); ok {
+			if _, isMap := existing.(map[string]any); !isMap {
+				return nil, fmt.Errorf("%w: duplicate key '%v'", ErrInvalidPath, k)
+			}
+			if vv, isMap := m[k].(map[string]any); !isMap || len(vv) != 0 {
+				return nil, fmt.Errorf("%w: '%v' is a prefix of other keys", ErrInvalidPath, k)
+			}
+			continue
+		}
+		if err := SetPathKeys(r, path, deepCopy(m[k])); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Flatten
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// DeepEqual
+
+// DeepEqual compares two trees and returns true if they are equal. It also
+// returns the sorted list of dot-separated paths where the trees differ,
+// including paths only present in one of them. List elements are identified
+// by their index.
+func DeepEqual(a, b map[string]any) (bool, []string) {
+	var diffs []string
+	deepDiff(&diffs, "", a, b)
+	sort.Strings(diffs)
+	return len(diffs) == 0, diffs
+}
+
+func deepDiff(diffs *[]string, path string, a, b any) {
+	var join = func(k string) string {
+		if path == "" {
+			return k
+		}
+		return path + "." + k
+	}
+
+	switch aa := a.(type) {
+	case map[string]any:
+		if bb, ok := b.(map[string]any); ok {
+			for k, v := range aa {
+				if vv, ok := bb[k]; ok {
+					deepDiff(diffs, join(k), v, vv)
+				} else {
+					*diffs = append(*diffs, join(k))
+				}
+			}
+			for k := range bb {
+				if _, ok := aa[k]; !ok {
+					*diffs = append(*diffs, join(k))
+				}
+			}
+			return
+		}
+	case []any:
+		if bb, ok := b.([]any); ok {
+			for i := 0; i < max(len(aa), len(bb)); i++ {
+				if i < len(aa) && i < len(bb) {
+					deepDiff(diffs, join(strconv.Itoa(i)), aa[i], bb[i])
+				} else {
+					*diffs = append(*diffs, join(strconv.Itoa(i)))
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, path)
+	}
+}
+
+// DeepEqual
+// ---------------------------------------------------------------------------
@@ -0,0 +1,158 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/maps"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func makeTree() map[string]any {
+	return map[string]any{
+		"name": "service",
+		"server": map[string]any{
+			"port": 8080,
+			"tls":  map[string]any{"enabled": false},
+		},
+		"tags": []any{"a", "b"},
+	}
+}
+
+func TestDeepMerge(t *testing.T) {
+	var dst = makeTree()
+	var src = map[string]any{
+		"server": map[string]any{
+			"tls": map[string]any{"enabled": true},
+		},
+		"tags": []any{"c"},
+	}
+	var r = maps.DeepMerge(dst, src, maps.MergeOptions{})
+	require.That(t, r).Eq(map[string]any{
+		"name": "service",
+		"server": map[string]any{
+			"port": 8080,
+			"tls":  map[string]any{"enabled": true},
+		},
+		"tags": []any{"c"},
+	})
+	require.That(t, dst).Eq(makeTree())
+}
+
+func TestDeepMergeStrategies(t *testing.T) {
+	var src = map[string]any{
+		"server": map[string]any{"host": "localhost"},
+		"tags":   []any{"c"},
+	}
+	var r = maps.DeepMerge(makeTree(), src, maps.MergeOptions{
+		Maps:  maps.MergeReplace,
+		Lists: maps.MergeAppend,
+	})
+	require.That(t, r).Field("server").Eq(map[string]any{"host": "localhost"})
+	require.That(t, r).Field("tags").Eq([]any{"a", "b", "c"})
+}
+
+func TestDeepMergeDoesNotAlias(t *testing.T) {
+	var src = map[string]any{"server": map[string]any{"port": 9090}}
+	var r = maps.DeepMerge(nil, src, maps.MergeOptions{})
+	r["server"].(map[string]any)["port"] = 1
+	require.That(t, src).Eq(map[string]any{"server": map[string]any{"port": 9090}})
+}
+
+func TestGetPath(t *testing.T) {
+	var m = makeTree()
+	var v, ok = maps.GetPath(m, "server.tls.enabled")
+	require.That(t, v).Eq(false)
+	require.That(t, ok).IsTrue()
+
+	v, ok = maps.GetPath(m, "tags.1")
+	require.That(t, v).Eq("b")
+	require.That(t, ok).IsTrue()
+
+	_, ok = maps.GetPath(m, "tags.2")
+	require.That(t, ok).IsFalse()
+	_, ok = maps.GetPath(m, "server.port.value")
+	require.That(t, ok).IsFalse()
+
+	m["dotted.key"] = 1
+	v, ok = maps.GetPathKeys(m, "dotted.key")
+	require.That(t, v).Eq(1)
+	require.That(t, ok).IsTrue()
+}
+
+func TestSetPath(t *testing.T) {
+	var m = makeTree()
+	require.That(t, maps.SetPath(m, "server.tls.cert", "cert.pem")).IsNil()
+	require.That(t, maps.SetPath(m, "logging.level", "debug")).IsNil()
+	require.That(t, maps.SetPathKeys(m, []string{"a.b"}, 1)).IsNil()
+
+	var v, _ = maps.GetPath(m, "server.tls.cert")
+	require.That(t, v).Eq("cert.pem")
+	v, _ = maps.GetPath(m, "logging.level")
+	require.That(t, v).Eq("debug")
+	require.That(t, m["a.b"]).Eq(1)
+
+	var err = maps.SetPath(m, "server.port.value", 1)
+	require.That(t, err).IsError(maps.ErrInvalidPath)
+	require.That(t, err).ToString().Contains("server.port")
+}
+
+func TestDeletePath(t *testing.T) {
+	var m = makeTree()
+	require.That(t, maps.DeletePath(m, "server.tls")).IsTrue()
+	require.That(t, maps.DeletePath(m, "server.tls")).IsFalse()
+	require.That(t, maps.DeletePath(m, "tags.0")).IsFalse()
+	require.That(t, m).Field("server").Eq(map[string]any{"port": 8080})
+}
+
+func TestFlatten(t *testing.T) {
+	var m = makeTree()
+	m["empty"] = map[string]any{}
+	var f = maps.Flatten(m, ".")
+	require.That(t, f).Eq(map[string]any{
+		"name":               "service",
+		"server.port":        8080,
+		"server.tls.enabled": false,
+		"tags":               []any{"a", "b"},
+		"empty":              map[string]any{},
+	})
+
+	var r, err = maps.Unflatten(f, ".")
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(m)
+}
+
+func TestUnflattenDoesNotModifyInput(t *testing.T) {
+	var empty = map[string]any{}
+	var r, err = maps.Unflatten(map[string]any{"a": empty, "a.b": 1}, ".")
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(map[string]any{"a": map[string]any{"b": 1}})
+	require.That(t, empty).IsEmpty()
+}
+
+func TestDeepMergeInapplicableStrategies(t *testing.T) {
+	var opts = maps.MergeOptions{Maps: maps.MergeAppend, Lists: maps.MergeRecurse}
+	var src = map[string]any{"server": map[string]any{"port": 9090}, "tags": []any{"z"}}
+	require.That(t, maps.DeepMerge(makeTree(), src, opts)).
+		Eq(maps.DeepMerge(makeTree(), src, maps.MergeOptions{}))
+}
+
+func TestUnflattenConflict(t *testing.T) {
+	var _, err = maps.Unflatten(map[string]any{"a": 1, "a/b": 2}, "/")
+	require.That(t, err).IsError(maps.ErrInvalidPath)
+}
+
+func TestDeepEqual(t *testing.T) {
+	var eq, diffs = maps.DeepEqual(makeTree(), makeTree())
+	require.That(t, eq).IsTrue()
+	require.That(t, diffs).IsEmpty()
+
+	var b = makeTree()
+	b["server"].(map[string]any)["port"] = 9090
+	b["tags"] = []any{"a", "c", "d"}
+	b["extra"] = true
+	delete(b, "name")
+
+	eq, diffs = maps.DeepEqual(makeTree(), b)
+	require.That(t, eq).IsFalse()
+	require.That(t, diffs).Eq([]string{"extra", "name", "server.port", "tags.1", "tags.2"})
+}
@@ -0,0 +1,84 @@
+package maps
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Entry is a key-value pair of a map.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// SortedKeys returns the keys of `m` in increasing order.
+func SortedKeys[K constraints.Ordered, V any](m map[K]V) []K {
+	var r = make([]K, 0, len(m))
+	for k := range m {
+		r = append(r, k)
+	}
+	slices.Sort(r)
+	return r
+}
+
+// SortedEntries returns the key-value pairs of `m` sorted by increasing key.
+func SortedEntries[K constraints.Ordered, V any](m map[K]V) []Entry[K, V] {
+	var r = make([]Entry[K, V], 0, len(m))
+	for _, k := range SortedKeys(m) {
+		r = append(r, Entry[K, V]{k, m[k]})
+	}
+	return r
+}
+
+// EachSorted invokes `f` on each key-value pair of `m`, in increasing key
+// order.
+func EachSorted[K constraints.Ordered, V any](m map[K]V, f func(k K, v V)) {
+	for _, k := range SortedKeys(m) {
+		f(k, m[k])
+	}
+}
+
+// EachSortedBy invokes `f` on each key-value pair of `m`, in the order defined
+// by the comparison function `less` on the keys. For the order to be
+// deterministic, `less` must define a strict total order.
+func EachSortedBy[K comparable, V any](m map[K]V, less func(a, b K) bool, f func(k K, v V)) {
+	var keys = make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, less)
+	for _, k := range keys {
+		f(k, m[k])
+	}
+}
+
+// MapSorted is a deterministic variant of Map() that invokes `f` in increasing
+// key order. If multiple pairs produce the same resulting key, the value
+// produced by the largest input key wins.
+func MapSorted[T constraints.Ordered, U any, R comparable, S any](
+	m map[T]U, f func(k T, v U) (R, S)) (
+	r map[R]S) {
+
+	r = make(map[R]S, len(m))
+	EachSorted(m, func(k T, v U) {
+		rk, rv := f(k, v)
+		r[rk] = rv
+	})
+	return r
+}
+
+// FlatMapSorted is a deterministic variant of FlatMap() that invokes `f` in
+// increasing key order. If multiple pairs produce the same resulting key, the
+// value produced by the largest input key wins.
+func FlatMapSorted[T constraints.Ordered, U any, R comparable, S any](
+	m map[T]U, f func(k T, v U) map[R]S) (
+	r map[R]S) {
+
+	r = make(map[R]S)
+	EachSorted(m, func(k T, v U) {
+		for rk, rv := range f(k, v) {
+			r[rk] = rv
+		}
+	})
+	return r
+}
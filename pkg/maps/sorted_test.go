@@ -0,0 +1,70 @@
+package maps_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/maps"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// repeat is the number of times each test of a deterministic function is run,
+// to make it very likely to detect any dependency on map iteration order.
+const repeat = 100
+
+var letters = map[string]int{"d": 4, "b": 2, "a": 1, "e": 5, "c": 3}
+
+func TestSortedKeys(t *testing.T) {
+	for i := 0; i < repeat; i++ {
+		require.That(t, maps.SortedKeys(letters)).Eq([]string{"a", "b", "c", "d", "e"})
+	}
+}
+
+func TestSortedEntries(t *testing.T) {
+	for i := 0; i < repeat; i++ {
+		require.That(t, maps.SortedEntries(letters)).Eq([]maps.Entry[string, int]{
+			{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}, {"e", 5},
+		})
+	}
+}
+
+func TestEachSorted(t *testing.T) {
+	for i := 0; i < repeat; i++ {
+		var values []int
+		maps.EachSorted(letters, func(k string, v int) {
+			values = append(values, v)
+		})
+		require.That(t, values).Eq([]int{1, 2, 3, 4, 5})
+	}
+}
+
+func TestEachSortedBy(t *testing.T) {
+	var greater = func(a, b string) bool { return a > b }
+	for i := 0; i < repeat; i++ {
+		var keys []string
+		maps.EachSortedBy(letters, greater, func(k string, v int) {
+			keys = append(keys, k)
+		})
+		require.That(t, keys).Eq([]string{"e", "d", "c", "b", "a"})
+	}
+}
+
+func TestMapSorted(t *testing.T) {
+	var parity = func(k string, v int) (int, string) {
+		return v % 2, k
+	}
+	for i := 0; i < repeat; i++ {
+		require.That(t, maps.MapSorted(letters, parity)).Eq(map[int]string{0: "d", 1: "e"})
+	}
+}
+
+func TestFlatMapSorted(t *testing.T) {
+	var f = func(k string, v int) map[string]int {
+		return map[string]int{"last": v, strings.ToUpper(k): v}
+	}
+	for i := 0; i < repeat; i++ {
+		var r = maps.FlatMapSorted(letters, f)
+		require.That(t, r).Field("last").Eq(5)
+		require.That(t, r).Length().Eq(6)
+	}
+}
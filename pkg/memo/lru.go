@@ -0,0 +1,21 @@
+package memo
+
+import "github.com/maargenton/go-generics/pkg/cache"
+
+// lruStore is a size-bounded store evicting the least recently used entry,
+// backed by a `cache.LRU`.
+type lruStore[K comparable, V any] struct {
+	c *cache.LRU[K, V]
+}
+
+func newLRUStore[K comparable, V any](size int) *lruStore[K, V] {
+	return &lruStore[K, V]{c: cache.NewLRU[K, V](size, nil)}
+}
+
+func (s *lruStore[K, V]) get(k K) (V, bool) {
+	return s.c.Get(k)
+}
+
+func (s *lruStore[K, V]) put(k K, v V) {
+	s.c.Put(k, v)
+}
@@ -0,0 +1,174 @@
+// Package memo provides memoization wrappers for pure functions, such as the
+// mapper callbacks used with the `slices` and `maps` packages. All wrappers
+// are safe for concurrent use, and concurrent calls with the same argument
+// are deduplicated so that the wrapped function is invoked only once.
+package memo
+
+import (
+	"sync"
+	"time"
+)
+
+// Memoize returns a function that invokes `f` once per distinct argument and
+// caches all the results for the lifetime of the returned function.
+func Memoize[K comparable, V any](f func(k K) V) func(k K) V {
+	return newMemoizer[K, V](&mapStore[K, V]{m: make(map[K]V)}, f).call
+}
+
+// LRU returns a function that invokes `f` and caches up to `size` results,
+// evicting the least recently used result when full. It panics if `size` is
+// not strictly positive.
+func LRU[K comparable, V any](size int, f func(k K) V) func(k K) V {
+	if size <= 0 {
+		panic("memo: LRU size must be strictly positive")
+	}
+	return newMemoizer[K, V](newLRUStore[K, V](size), f).call
+}
+
+// TTL returns a function that invokes `f` and caches each result for the
+// duration `ttl`. The current time is obtained by calling `now`, which
+// defaults to `time.Now` if nil, and can be replaced for testing. Expired
+// results are discarded lazily, when accessed.
+func TTL[K comparable, V any](ttl time.Duration, now func() time.Time, f func(k K) V) func(k K) V {
+	if now == nil {
+		now = time.Now
+	}
+	return newMemoizer[K, V](&ttlStore[K, V]{
+		m:   make(map[K]ttlEntry[V]),
+		ttl: ttl,
+		now: now,
+	}, f).call
+}
+
+// Singleflight returns a function that deduplicates concurrent calls to `f`
+// with the same argument, without caching the results: callers arriving while
+// a call is in flight wait for it and share its result.
+func Singleflight[K comparable, V any](f func(k K) V) func(k K) V {
+	return newMemoizer[K, V](noStore[K, V]{}, f).call
+}
+
+// ---------------------------------------------------------------------------
+// memoizer
+
+// store is the caching policy of a memoizer. Its methods are always invoked
+// with the memoizer lock held.
+type store[K comparable, V any] interface {
+	get(k K) (V, bool)
+	put(k K, v V)
+}
+
+// call tracks an in-flight invocation of the memoized function.
+type call[V any] struct {
+	wg       sync.WaitGroup
+	v        V
+	panicked bool
+	panicv   any
+}
+
+type memoizer[K comparable, V any] struct {
+	mu    sync.Mutex
+	store store[K, V]
+	calls map[K]*call[V]
+	f     func(k K) V
+}
+
+func newMemoizer[K comparable, V any](s store[K, V], f func(k K) V) *memoizer[K, V] {
+	return &memoizer[K, V]{
+		store: s,
+		calls: make(map[K]*call[V]),
+		f:     f,
+	}
+}
+
+func (m *memoizer[K, V]) call(k K) V {
+	m.mu.Lock()
+	if v, ok := m.store.get(k); ok {
+		m.mu.Unlock()
+		return v
+	}
+	if c, ok := m.calls[k]; ok {
+		m.mu.Unlock()
+		c.wg.Wait()
+		if c.panicked {
+			panic(c.panicv)
+		}
+		return c.v
+	}
+	var c = &call[V]{}
+	c.wg.Add(1)
+	m.calls[k] = c
+	m.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			c.panicked = true
+			c.panicv = r
+		}
+		m.mu.Lock()
+		delete(m.calls, k)
+		if !c.panicked {
+			m.store.put(k, c.v)
+		}
+		m.mu.Unlock()
+		c.wg.Done()
+		if c.panicked {
+			panic(c.panicv)
+		}
+	}()
+	c.v = m.f(k)
+	return c.v
+}
+
+// memoizer
+// ---------------------------------------------------------------------------
+
+// ---------------------------------------------------------------------------
+// stores
+
+type noStore[K comparable, V any] struct{}
+
+func (noStore[K, V]) get(k K) (v V, ok bool) { return v, false }
+func (noStore[K, V]) put(k K, v V)           {}
+
+type mapStore[K comparable, V any] struct {
+	m map[K]V
+}
+
+func (s *mapStore[K, V]) get(k K) (V, bool) {
+	var v, ok = s.m[k]
+	return v, ok
+}
+
+func (s *mapStore[K, V]) put(k K, v V) {
+	s.m[k] = v
+}
+
+type ttlEntry[V any] struct {
+	v       V
+	expires time.Time
+}
+
+type ttlStore[K comparable, V any] struct {
+	m   map[K]ttlEntry[V]
+	ttl time.Duration
+	now func() time.Time
+}
+
+func (s *ttlStore[K, V]) get(k K) (v V, ok bool) {
+	var e, found = s.m[k]
+	if !found {
+		return v, false
+	}
+	if !s.now().Before(e.expires) {
+		delete(s.m, k)
+		return v, false
+	}
+	return e.v, true
+}
+
+func (s *ttlStore[K, V]) put(k K, v V) {
+	s.m[k] = ttlEntry[V]{v: v, expires: s.now().Add(s.ttl)}
+}
+
+// stores
+// ---------------------------------------------------------------------------
@@ -0,0 +1,155 @@
+package memo_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maargenton/go-generics/pkg/memo"
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// counted returns a function squaring its input, and a counter of the number
+// of times the function was invoked for each input.
+func counted() (func(k int) int, func(k int) int) {
+	var mu sync.Mutex
+	var counts = make(map[int]int)
+	var f = func(k int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[k]++
+		return k * k
+	}
+	var count = func(k int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[k]
+	}
+	return f, count
+}
+
+func TestMemoize(t *testing.T) {
+	var f, count = counted()
+	var r = slices.Map([]int{1, 2, 1, 3, 2, 1}, memo.Memoize(f))
+	require.That(t, r).Eq([]int{1, 4, 1, 9, 4, 1})
+	require.That(t, count(1)).Eq(1)
+	require.That(t, count(2)).Eq(1)
+	require.That(t, count(3)).Eq(1)
+}
+
+func TestLRU(t *testing.T) {
+	var f, count = counted()
+	var m = memo.LRU(2, f)
+	m(1)
+	m(2)
+	m(1) // 1 becomes most recently used
+	m(3) // evicts 2
+	m(1)
+	m(2)
+	require.That(t, count(1)).Eq(1)
+	require.That(t, count(2)).Eq(2)
+	require.That(t, count(3)).Eq(1)
+	require.That(t, func() { memo.LRU(0, f) }).Panics()
+}
+
+func TestTTL(t *testing.T) {
+	var f, count = counted()
+	var now = time.Unix(0, 0)
+	var clock = func() time.Time { return now }
+	var m = memo.TTL(time.Minute, clock, f)
+
+	require.That(t, m(2)).Eq(4)
+	now = now.Add(59 * time.Second)
+	require.That(t, m(2)).Eq(4)
+	require.That(t, count(2)).Eq(1)
+
+	now = now.Add(time.Second)
+	require.That(t, m(2)).Eq(4)
+	require.That(t, count(2)).Eq(2)
+}
+
+func TestSingleflightDoesNotCache(t *testing.T) {
+	var f, count = counted()
+	var m = memo.Singleflight(f)
+	m(1)
+	m(1)
+	require.That(t, count(1)).Eq(2)
+}
+
+func TestPanicIsPropagatedAndNotCached(t *testing.T) {
+	var calls = 0
+	var m = memo.Memoize(func(k int) int {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return k
+	})
+	require.That(t, func() { m(1) }).Panics()
+	require.That(t, m(1)).Eq(1)
+}
+
+// concurrently invokes `f` with a small set of keys from many goroutines.
+// Each key is first requested while the underlying function is blocked, so
+// that calls overlap.
+func concurrently(f func(k int) int, release chan struct{}) []int {
+	var wg sync.WaitGroup
+	var results = make([]int, 100)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = f(i % 4)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	return results
+}
+
+func TestConcurrentCallsAreDeduplicated(t *testing.T) {
+	var wrappers = map[string]func(f func(k int) int) func(k int) int{
+		"Memoize": memo.Memoize[int, int],
+		"LRU": func(f func(k int) int) func(k int) int {
+			return memo.LRU(10, f)
+		},
+		"TTL": func(f func(k int) int) func(k int) int {
+			return memo.TTL(time.Hour, nil, f)
+		},
+	}
+	for name, wrap := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			var release = make(chan struct{})
+			var m = wrap(func(k int) int {
+				calls.Add(1)
+				<-release
+				return k * k
+			})
+			var results = concurrently(m, release)
+			for i, r := range results {
+				require.That(t, r).Eq((i % 4) * (i % 4))
+			}
+			require.That(t, int(calls.Load())).Eq(4)
+		})
+	}
+}
+
+func TestSingleflightConcurrentCalls(t *testing.T) {
+	var calls atomic.Int32
+	var release = make(chan struct{})
+	var m = memo.Singleflight(func(k int) int {
+		calls.Add(1)
+		<-release
+		return -k
+	})
+	var results = concurrently(m, release)
+	for i, r := range results {
+		require.That(t, r).Eq(-(i % 4))
+	}
+	require.That(t, int(calls.Load())).Le(100)
+	require.That(t, int(calls.Load())).Ge(4)
+}
@@ -0,0 +1,196 @@
+package multimap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotOneToOne is returned when building a BiMap from pairs that would
+// associate the same value with more than one key.
+var ErrNotOneToOne = errors.New("multimap: mapping is not one-to-one")
+
+// BiMap is a bidirectional map enforcing a one-to-one relationship between
+// keys and values, allowing efficient lookup in both directions.
+type BiMap[K comparable, V comparable] struct {
+	fwd map[K]V
+	rev map[V]K
+}
+
+// NewBiMap creates a new empty bidirectional map.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		fwd: make(map[K]V),
+		rev: make(map[V]K),
+	}
+}
+
+// BiMapFromMap creates a new bidirectional map from the key-value pairs of
+// `m`. It fails with ErrNotOneToOne if multiple keys of `m` share the same
+// value.
+func BiMapFromMap[K comparable, V comparable](m map[K]V) (*BiMap[K, V], error) {
+	var r = NewBiMap[K, V]()
+	for k, v := range m {
+		if !r.Insert(k, v) {
+			return nil, fmt.Errorf("%w: duplicate value %v", ErrNotOneToOne, v)
+		}
+	}
+	return r, nil
+}
+
+// Put associates `k` with `v`, removing any previous association of either
+// `k` or `v` to preserve the one-to-one relationship.
+func (b *BiMap[K, V]) Put(k K, v V) {
+	b.RemoveKey(k)
+	b.RemoveValue(v)
+	b.fwd[k] = v
+	b.rev[v] = k
+}
+
+// Insert associates `k` with `v` only if neither of them is already present,
+// and returns true if the association was added.
+func (b *BiMap[K, V]) Insert(k K, v V) bool {
+	if _, ok := b.fwd[k]; ok {
+		return false
+	}
+	if _, ok := b.rev[v]; ok {
+		return false
+	}
+	b.fwd[k] = v
+	b.rev[v] = k
+	return true
+}
+
+// merge is a variant of Insert() that also succeeds if the exact same
+// association is already present.
+func (b *BiMap[K, V]) merge(k K, v V) bool {
+	if vv, ok := b.fwd[k]; ok && vv == v {
+		return true
+	}
+	return b.Insert(k, v)
+}
+
+// Get returns the value associated with `k`. The second return value is false
+// if `k` is not present.
+func (b *BiMap[K, V]) Get(k K) (V, bool) {
+	var v, ok = b.fwd[k]
+	return v, ok
+}
+
+// GetKey returns the key associated with `v`. The second return value is
+// false if `v` is not present.
+func (b *BiMap[K, V]) GetKey(v V) (K, bool) {
+	var k, ok = b.rev[v]
+	return k, ok
+}
+
+// RemoveKey removes `k` and its associated value, and returns true if `k` was
+// present.
+func (b *BiMap[K, V]) RemoveKey(k K) bool {
+	var v, ok = b.fwd[k]
+	if ok {
+		delete(b.fwd, k)
+		delete(b.rev, v)
+	}
+	return ok
+}
+
+// RemoveValue removes `v` and its associated key, and returns true if `v` was
+// present.
+func (b *BiMap[K, V]) RemoveValue(v V) bool {
+	var k, ok = b.rev[v]
+	if ok {
+		delete(b.rev, v)
+		delete(b.fwd, k)
+	}
+	return ok
+}
+
+// Len returns the number of key-value pairs in the map.
+func (b *BiMap[K, V]) Len() int {
+	return len(b.fwd)
+}
+
+// Keys returns the keys of the map, in no particular order.
+func (b *BiMap[K, V]) Keys() []K {
+	var r = make([]K, 0, len(b.fwd))
+	for k := range b.fwd {
+		r = append(r, k)
+	}
+	return r
+}
+
+// Values returns the values of the map, in no particular order.
+func (b *BiMap[K, V]) Values() []V {
+	var r = make([]V, 0, len(b.rev))
+	for v := range b.rev {
+		r = append(r, v)
+	}
+	return r
+}
+
+// Inverse returns a view of the map with keys and values swapped. The view
+// shares its storage with the original map, so that changes made through
+// either are visible through both.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return &BiMap[V, K]{fwd: b.rev, rev: b.fwd}
+}
+
+// ToMap returns a copy of the content of the map as a plain map.
+func (b *BiMap[K, V]) ToMap() map[K]V {
+	var r = make(map[K]V, len(b.fwd))
+	for k, v := range b.fwd {
+		r[k] = v
+	}
+	return r
+}
+
+// MapBi invokes `f` on each key-value pair of `b` and collects the returned
+// keys and values into a new bidirectional map. It fails with ErrNotOneToOne
+// if the results are not one-to-one.
+func MapBi[K comparable, V comparable, R comparable, S comparable](
+	b *BiMap[K, V], f func(k K, v V) (R, S)) (
+	r *BiMap[R, S], err error) {
+
+	r = NewBiMap[R, S]()
+	for k, v := range b.fwd {
+		var rk, rv = f(k, v)
+		if !r.merge(rk, rv) {
+			return nil, fmt.Errorf("%w: duplicate key %v or value %v", ErrNotOneToOne, rk, rv)
+		}
+	}
+	return r, nil
+}
+
+// FlatMapBi invokes `f` on each key-value pair of `b` and collects the
+// returned keys and values into a new bidirectional map. In this variant, `f`
+// return a map of results with 0, 1 or more key-value pairs. It fails with
+// ErrNotOneToOne if the results are not one-to-one.
+func FlatMapBi[K comparable, V comparable, R comparable, S comparable](
+	b *BiMap[K, V], f func(k K, v V) map[R]S) (
+	r *BiMap[R, S], err error) {
+
+	r = NewBiMap[R, S]()
+	for k, v := range b.fwd {
+		for rk, rv := range f(k, v) {
+			if !r.merge(rk, rv) {
+				return nil, fmt.Errorf("%w: duplicate key %v or value %v", ErrNotOneToOne, rk, rv)
+			}
+		}
+	}
+	return r, nil
+}
+
+// FilterBi invokes `f` on each key-value pair of `b` and collects into a new
+// bidirectional map the keys and values for which `f` return true.
+func FilterBi[K comparable, V comparable](
+	b *BiMap[K, V], f func(k K, v V) bool) (
+	r *BiMap[K, V]) {
+
+	r = NewBiMap[K, V]()
+	for k, v := range b.fwd {
+		if f(k, v) {
+			r.Insert(k, v)
+		}
+	}
+	return r
+}
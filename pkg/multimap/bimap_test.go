@@ -0,0 +1,89 @@
+package multimap_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/multimap"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestBiMapPut(t *testing.T) {
+	var b = multimap.NewBiMap[string, int]()
+	b.Put("a", 1)
+	b.Put("b", 2)
+	b.Put("c", 1)
+	require.That(t, b.Len()).Eq(2)
+	require.That(t, b.ToMap()).Eq(map[string]int{"b": 2, "c": 1})
+
+	var k, ok = b.GetKey(1)
+	require.That(t, k).Eq("c")
+	require.That(t, ok).IsTrue()
+	_, ok = b.Get("a")
+	require.That(t, ok).IsFalse()
+}
+
+func TestBiMapInsert(t *testing.T) {
+	var b = multimap.NewBiMap[string, int]()
+	require.That(t, b.Insert("a", 1)).IsTrue()
+	require.That(t, b.Insert("a", 2)).IsFalse()
+	require.That(t, b.Insert("b", 1)).IsFalse()
+	require.That(t, b.ToMap()).Eq(map[string]int{"a": 1})
+}
+
+func TestBiMapRemove(t *testing.T) {
+	var b = multimap.NewBiMap[string, int]()
+	b.Put("a", 1)
+	b.Put("b", 2)
+	require.That(t, b.RemoveKey("a")).IsTrue()
+	require.That(t, b.RemoveValue(2)).IsTrue()
+	require.That(t, b.RemoveValue(2)).IsFalse()
+	require.That(t, b.Len()).Eq(0)
+	require.That(t, b.Inverse().Len()).Eq(0)
+}
+
+func TestBiMapInverse(t *testing.T) {
+	var b, err = multimap.BiMapFromMap(map[string]int{"a": 1, "b": 2})
+	require.That(t, err).IsNil()
+
+	var inv = b.Inverse()
+	require.That(t, inv.ToMap()).Eq(map[int]string{1: "a", 2: "b"})
+	require.That(t, inv.Keys()).IsEqualSet([]int{1, 2})
+	require.That(t, inv.Values()).IsEqualSet([]string{"a", "b"})
+
+	inv.Put(3, "c")
+	var v, _ = b.Get("c")
+	require.That(t, v).Eq(3)
+}
+
+func TestBiMapFromMapNotOneToOne(t *testing.T) {
+	var b, err = multimap.BiMapFromMap(map[string]int{"a": 1, "b": 1})
+	require.That(t, err).IsError(multimap.ErrNotOneToOne)
+	require.That(t, b).IsNil()
+}
+
+func TestBiMapMapAndFilter(t *testing.T) {
+	var b, _ = multimap.BiMapFromMap(map[string]int{"a": 1, "b": 2})
+
+	var r, err = multimap.MapBi(b, func(k string, v int) (int, string) {
+		return v * 10, k + k
+	})
+	require.That(t, err).IsNil()
+	require.That(t, r.ToMap()).Eq(map[int]string{10: "aa", 20: "bb"})
+
+	_, err = multimap.MapBi(b, func(k string, v int) (string, int) {
+		return k, 0
+	})
+	require.That(t, err).IsError(multimap.ErrNotOneToOne)
+
+	var f = multimap.FilterBi(b, func(k string, v int) bool { return v > 1 })
+	require.That(t, f.ToMap()).Eq(map[string]int{"b": 2})
+}
+
+func TestBiMapFlatMap(t *testing.T) {
+	var b, _ = multimap.BiMapFromMap(map[string]int{"a": 1, "b": 2})
+	var r, err = multimap.FlatMapBi(b, func(k string, v int) map[string]int {
+		return map[string]int{k: v, "shared": 0}
+	})
+	require.That(t, err).IsNil()
+	require.That(t, r.ToMap()).Eq(map[string]int{"a": 1, "b": 2, "shared": 0})
+}
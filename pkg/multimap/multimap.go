@@ -0,0 +1,165 @@
+package multimap
+
+// MultiMap is a map that associates each key with an ordered list of values.
+// Values can be repeated under the same key. Keys with no values are never
+// reported.
+type MultiMap[K comparable, V comparable] struct {
+	m   map[K][]V
+	len int
+}
+
+// New creates a new empty multimap.
+func New[K comparable, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{m: make(map[K][]V)}
+}
+
+// FromGroupBy creates a new multimap from a map of slices, such as the result
+// of `slices.GroupBy()`. The input is copied and not retained.
+func FromGroupBy[K comparable, V comparable](m map[K][]V) *MultiMap[K, V] {
+	var r = New[K, V]()
+	for k, v := range m {
+		r.Add(k, v...)
+	}
+	return r
+}
+
+// FromMap creates a new multimap with a single value for each key of `m`.
+func FromMap[K comparable, V comparable](m map[K]V) *MultiMap[K, V] {
+	var r = New[K, V]()
+	for k, v := range m {
+		r.Add(k, v)
+	}
+	return r
+}
+
+// Add appends the values `v` to the list of values associated with `k`.
+func (m *MultiMap[K, V]) Add(k K, v ...V) {
+	if len(v) == 0 {
+		return
+	}
+	m.m[k] = append(m.m[k], v...)
+	m.len += len(v)
+}
+
+// Remove removes the first occurrence of `v` from the values associated with
+// `k`, and returns true if a value was removed.
+func (m *MultiMap[K, V]) Remove(k K, v V) bool {
+	var values = m.m[k]
+	for i, vv := range values {
+		if vv == v {
+			if len(values) == 1 {
+				delete(m.m, k)
+			} else {
+				m.m[k] = append(values[:i:i], values[i+1:]...)
+			}
+			m.len--
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAll removes all the values associated with `k` and returns them.
+func (m *MultiMap[K, V]) RemoveAll(k K) []V {
+	var values = m.m[k]
+	delete(m.m, k)
+	m.len -= len(values)
+	return values
+}
+
+// Get returns a copy of the values associated with `k`, in insertion order.
+func (m *MultiMap[K, V]) Get(k K) []V {
+	return append([]V(nil), m.m[k]...)
+}
+
+// Contains returns true if `v` is one of the values associated with `k`.
+func (m *MultiMap[K, V]) Contains(k K, v V) bool {
+	for _, vv := range m.m[k] {
+		if vv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of the multimap, in no particular order.
+func (m *MultiMap[K, V]) Keys() []K {
+	var r = make([]K, 0, len(m.m))
+	for k := range m.m {
+		r = append(r, k)
+	}
+	return r
+}
+
+// Len returns the total number of values in the multimap, across all keys.
+func (m *MultiMap[K, V]) Len() int {
+	return m.len
+}
+
+// KeyLen returns the number of distinct keys in the multimap.
+func (m *MultiMap[K, V]) KeyLen() int {
+	return len(m.m)
+}
+
+// Each invokes `f` with each key-value pair of the multimap.
+func (m *MultiMap[K, V]) Each(f func(k K, v V)) {
+	for k, values := range m.m {
+		for _, v := range values {
+			f(k, v)
+		}
+	}
+}
+
+// ToMap returns a copy of the content of the multimap as a map of slices,
+// in the same format as `slices.GroupBy()`.
+func (m *MultiMap[K, V]) ToMap() map[K][]V {
+	var r = make(map[K][]V, len(m.m))
+	for k, v := range m.m {
+		r[k] = append([]V(nil), v...)
+	}
+	return r
+}
+
+// Map invokes `f` on each key-value pair of `m` and collects the returned keys
+// and values into a new multimap.
+func Map[K comparable, V comparable, R comparable, S comparable](
+	m *MultiMap[K, V], f func(k K, v V) (R, S)) (
+	r *MultiMap[R, S]) {
+
+	r = New[R, S]()
+	m.Each(func(k K, v V) {
+		r.Add(f(k, v))
+	})
+	return r
+}
+
+// FlatMap invokes `f` on each key-value pair of `m` and collects the returned
+// keys and values into a new multimap. In this variant, `f` return a map of
+// results with 0, 1 or more values per key.
+func FlatMap[K comparable, V comparable, R comparable, S comparable](
+	m *MultiMap[K, V], f func(k K, v V) map[R][]S) (
+	r *MultiMap[R, S]) {
+
+	r = New[R, S]()
+	m.Each(func(k K, v V) {
+		for rk, rv := range f(k, v) {
+			r.Add(rk, rv...)
+		}
+	})
+	return r
+}
+
+// Filter invokes `f` on each key-value pair of `m` and collects into a new
+// multimap the keys and values for which `f` return true.
+func Filter[K comparable, V comparable](
+	m *MultiMap[K, V], f func(k K, v V) bool) (
+	r *MultiMap[K, V]) {
+
+	r = New[K, V]()
+	m.Each(func(k K, v V) {
+		if f(k, v) {
+			r.Add(k, v)
+		}
+	})
+	return r
+}
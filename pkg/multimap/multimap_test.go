@@ -0,0 +1,75 @@
+package multimap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/multimap"
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestMultiMapAddRemove(t *testing.T) {
+	var m = multimap.New[string, int]()
+	m.Add("a", 1, 2, 1)
+	m.Add("b", 3)
+	m.Add("c")
+	require.That(t, m.Len()).Eq(4)
+	require.That(t, m.KeyLen()).Eq(2)
+	require.That(t, m.Keys()).IsEqualSet([]string{"a", "b"})
+	require.That(t, m.Get("a")).Eq([]int{1, 2, 1})
+	require.That(t, m.Contains("a", 2)).IsTrue()
+
+	require.That(t, m.Remove("a", 1)).IsTrue()
+	require.That(t, m.Get("a")).Eq([]int{2, 1})
+	require.That(t, m.Remove("a", 3)).IsFalse()
+	require.That(t, m.Remove("b", 3)).IsTrue()
+	require.That(t, m.Keys()).Eq([]string{"a"})
+	require.That(t, m.Len()).Eq(2)
+
+	require.That(t, m.RemoveAll("a")).Eq([]int{2, 1})
+	require.That(t, m.Len()).Eq(0)
+	require.That(t, m.Get("a")).IsEmpty()
+}
+
+func TestMultiMapGetReturnsCopy(t *testing.T) {
+	var m = multimap.New[string, int]()
+	m.Add("a", 1, 2)
+	var v = m.Get("a")
+	v[0] = -1
+	require.That(t, m.Get("a")).Eq([]int{1, 2})
+}
+
+func TestMultiMapConversions(t *testing.T) {
+	var v = []int{0, 1, 2, 3, 4}
+	var groups = slices.GroupBy(v, func(a int) bool { return a%2 == 0 })
+	var m = multimap.FromGroupBy(groups)
+	require.That(t, m.Len()).Eq(5)
+	require.That(t, m.ToMap()).Eq(groups)
+
+	var m2 = multimap.FromMap(map[string]int{"a": 1, "b": 2})
+	require.That(t, m2.ToMap()).Eq(map[string][]int{"a": {1}, "b": {2}})
+}
+
+func TestMultiMapMap(t *testing.T) {
+	var m = multimap.FromMap(map[string]int{"a": 1, "b": 2})
+	var r = multimap.Map(m, func(k string, v int) (string, int) {
+		return "x", v * 10
+	})
+	require.That(t, r.Get("x")).IsEqualSet([]int{10, 20})
+}
+
+func TestMultiMapFlatMap(t *testing.T) {
+	var m = multimap.FromMap(map[string]int{"a": 1})
+	var r = multimap.FlatMap(m, func(k string, v int) map[string][]int {
+		return map[string][]int{k: {v, v}, strings.ToUpper(k): {-v}}
+	})
+	require.That(t, r.ToMap()).Eq(map[string][]int{"a": {1, 1}, "A": {-1}})
+}
+
+func TestMultiMapFilter(t *testing.T) {
+	var m = multimap.New[string, int]()
+	m.Add("a", 1, 2, 3, 4)
+	var r = multimap.Filter(m, func(k string, v int) bool { return v%2 == 0 })
+	require.That(t, r.ToMap()).Eq(map[string][]int{"a": {2, 4}})
+}
@@ -0,0 +1,363 @@
+package persistent
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+
+	"github.com/maargenton/go-generics/internal/hashing"
+)
+
+var seed = maphash.MakeSeed()
+
+// hashBits is the number of bits of the key hashes; nodes deeper than that
+// hold colliding keys in a plain list.
+const hashBits = 64
+
+// Map is an immutable map, stored as a hash array mapped trie (HAMT) with 32
+// branches per node. Lookups and updates run in O(log32 n), which is
+// effectively constant. The zero value is an empty map, ready to use.
+type Map[K comparable, V any] struct {
+	count int
+	root  *hnode[K, V]
+}
+
+// hnode is a trie node where `bitmap` indicates which of the 32 branches are
+// populated, each populated branch holding either a single key-value pair or a
+// child node. Below `hashBits`, the bitmap is unused and entries are colliding
+// key-value pairs.
+type hnode[K comparable, V any] struct {
+	edit    *owner
+	bitmap  uint32
+	entries []hentry[K, V]
+}
+
+type hentry[K comparable, V any] struct {
+	key   K
+	value V
+	child *hnode[K, V]
+}
+
+func hash[K comparable](k K) uint64 {
+	return hashing.Comparable(seed, k)
+}
+
+// branch returns the bit associated with hash `h` at depth `shift`, and the
+// index of the corresponding entry.
+func (n *hnode[K, V]) branch(h uint64, shift uint) (bit uint32, i int) {
+	bit = 1 << ((h >> shift) & branchMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// MapOf returns a new map containing the key-value pairs of `m`.
+func MapOf[K comparable, V any](m map[K]V) Map[K, V] {
+	var b MapBuilder[K, V]
+	for k, v := range m {
+		b.Set(k, v)
+	}
+	return b.Map()
+}
+
+// Len returns the number of entries in the map.
+func (m Map[K, V]) Len() int {
+	return m.count
+}
+
+// Get returns the value associated with `k`. The second return value is false
+// if `k` is not in the map.
+func (m Map[K, V]) Get(k K) (v V, ok bool) {
+	if m.root == nil {
+		return v, false
+	}
+	var h = hash(k)
+	var n = m.root
+	for shift := uint(0); ; shift += branchBits {
+		if shift >= hashBits {
+			for _, e := range n.entries {
+				if e.key == k {
+					return e.value, true
+				}
+			}
+			return v, false
+		}
+		var bit, i = n.branch(h, shift)
+		if n.bitmap&bit == 0 {
+			return v, false
+		}
+		var e = &n.entries[i]
+		if e.child == nil {
+			if e.key == k {
+				return e.value, true
+			}
+			return v, false
+		}
+		n = e.child
+	}
+}
+
+// Contains returns true if `k` is in the map.
+func (m Map[K, V]) Contains(k K) bool {
+	var _, ok = m.Get(k)
+	return ok
+}
+
+// Set returns a new map where `k` is associated with `v`.
+func (m Map[K, V]) Set(k K, v V) Map[K, V] {
+	var b = m.Builder()
+	b.Set(k, v)
+	return b.Map()
+}
+
+// Delete returns a new map without `k`. The map itself is returned if `k` is
+// not present.
+func (m Map[K, V]) Delete(k K) Map[K, V] {
+	var b = m.Builder()
+	b.Delete(k)
+	return b.Map()
+}
+
+// All returns an iterator over the key-value pairs of the map, in no
+// particular order.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.each(yield)
+		}
+	}
+}
+
+func (n *hnode[K, V]) each(yield func(K, V) bool) bool {
+	for i := range n.entries {
+		var e = &n.entries[i]
+		if e.child != nil {
+			if !e.child.each(yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns the keys of the map as a newly allocated slice, in no
+// particular order.
+func (m Map[K, V]) Keys() []K {
+	var r = make([]K, 0, m.count)
+	for k := range m.All() {
+		r = append(r, k)
+	}
+	return r
+}
+
+// ToMap returns the content of the map as a newly allocated native map.
+func (m Map[K, V]) ToMap() map[K]V {
+	var r = make(map[K]V, m.count)
+	for k, v := range m.All() {
+		r[k] = v
+	}
+	return r
+}
+
+// Builder returns a new builder initialized with the content of the map. The
+// map itself is not affected by changes made through the builder.
+func (m Map[K, V]) Builder() *MapBuilder[K, V] {
+	return &MapBuilder[K, V]{m: m}
+}
+
+// ---------------------------------------------------------------------------
+// MapBuilder
+
+// MapBuilder applies a batch of updates to a map in place, only copying the
+// parts of the structure shared with previously produced maps. The zero value
+// is an empty builder, ready to use. A builder must not be copied or used
+// concurrently.
+type MapBuilder[K comparable, V any] struct {
+	m    Map[K, V]
+	edit *owner
+}
+
+// Len returns the number of entries in the builder.
+func (b *MapBuilder[K, V]) Len() int {
+	return b.m.count
+}
+
+// Get returns the value associated with `k`. The second return value is false
+// if `k` is not in the builder.
+func (b *MapBuilder[K, V]) Get(k K) (V, bool) {
+	return b.m.Get(k)
+}
+
+// Set associates `v` with `k`.
+func (b *MapBuilder[K, V]) Set(k K, v V) {
+	var root = b.m.root
+	if root == nil {
+		root = &hnode[K, V]{edit: b.owner()}
+	}
+	var added bool
+	b.m.root, added = b.set(root, 0, hash(k), k, v)
+	if added {
+		b.m.count++
+	}
+}
+
+// Delete removes `k` and returns true if it was present.
+func (b *MapBuilder[K, V]) Delete(k K) bool {
+	if b.m.root == nil {
+		return false
+	}
+	var root, removed = b.delete(b.m.root, 0, hash(k), k)
+	if removed {
+		b.m.root = root
+		b.m.count--
+	}
+	return removed
+}
+
+// Map returns an immutable map with the current content of the builder. The
+// builder remains usable, and further changes do not affect the returned map.
+func (b *MapBuilder[K, V]) Map() Map[K, V] {
+	b.edit = nil
+	return b.m
+}
+
+func (b *MapBuilder[K, V]) owner() *owner {
+	if b.edit == nil {
+		b.edit = &owner{}
+	}
+	return b.edit
+}
+
+func (b *MapBuilder[K, V]) editable(n *hnode[K, V]) *hnode[K, V] {
+	if n.edit != nil && n.edit == b.edit {
+		return n
+	}
+	return &hnode[K, V]{
+		edit:    b.owner(),
+		bitmap:  n.bitmap,
+		entries: append(make([]hentry[K, V], 0, len(n.entries)+1), n.entries...),
+	}
+}
+
+func (b *MapBuilder[K, V]) set(n *hnode[K, V], shift uint, h uint64, k K, v V) (*hnode[K, V], bool) {
+	if shift >= hashBits {
+		for i := range n.entries {
+			if n.entries[i].key == k {
+				n = b.editable(n)
+				n.entries[i].value = v
+				return n, false
+			}
+		}
+		n = b.editable(n)
+		n.entries = append(n.entries, hentry[K, V]{key: k, value: v})
+		return n, true
+	}
+
+	var bit, i = n.branch(h, shift)
+	if n.bitmap&bit == 0 {
+		n = b.editable(n)
+		n.entries = append(n.entries, hentry[K, V]{})
+		copy(n.entries[i+1:], n.entries[i:])
+		n.entries[i] = hentry[K, V]{key: k, value: v}
+		n.bitmap |= bit
+		return n, true
+	}
+
+	var e = n.entries[i]
+	switch {
+	case e.child != nil:
+		var child, added = b.set(e.child, shift+branchBits, h, k, v)
+		n = b.editable(n)
+		n.entries[i].child = child
+		return n, added
+	case e.key == k:
+		n = b.editable(n)
+		n.entries[i].value = v
+		return n, false
+	default:
+		var child = b.pair(shift+branchBits, e.key, e.value, hash(e.key), k, v, h)
+		n = b.editable(n)
+		n.entries[i] = hentry[K, V]{child: child}
+		return n, true
+	}
+}
+
+// pair returns a new node at depth `shift` containing two distinct keys.
+func (b *MapBuilder[K, V]) pair(shift uint, k1 K, v1 V, h1 uint64, k2 K, v2 V, h2 uint64) *hnode[K, V] {
+	var n = &hnode[K, V]{edit: b.owner()}
+	var e1 = hentry[K, V]{key: k1, value: v1}
+	var e2 = hentry[K, V]{key: k2, value: v2}
+	if shift >= hashBits {
+		n.entries = []hentry[K, V]{e1, e2}
+		return n
+	}
+	var b1, b2 = (h1 >> shift) & branchMask, (h2 >> shift) & branchMask
+	switch {
+	case b1 == b2:
+		n.bitmap = 1 << b1
+		n.entries = []hentry[K, V]{{child: b.pair(shift+branchBits, k1, v1, h1, k2, v2, h2)}}
+	case b1 < b2:
+		n.bitmap = 1<<b1 | 1<<b2
+		n.entries = []hentry[K, V]{e1, e2}
+	default:
+		n.bitmap = 1<<b1 | 1<<b2
+		n.entries = []hentry[K, V]{e2, e1}
+	}
+	return n
+}
+
+// delete removes `k` from the subtree rooted at `n`, returning the new
+// subtree, or nil if it becomes empty.
+func (b *MapBuilder[K, V]) delete(n *hnode[K, V], shift uint, h uint64, k K) (*hnode[K, V], bool) {
+	if shift >= hashBits {
+		for i := range n.entries {
+			if n.entries[i].key == k {
+				return b.remove(n, i, 0), true
+			}
+		}
+		return n, false
+	}
+
+	var bit, i = n.branch(h, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	var e = n.entries[i]
+	if e.child == nil {
+		if e.key != k {
+			return n, false
+		}
+		return b.remove(n, i, bit), true
+	}
+
+	var child, removed = b.delete(e.child, shift+branchBits, h, k)
+	if !removed {
+		return n, false
+	}
+	if child == nil {
+		return b.remove(n, i, bit), true
+	}
+	n = b.editable(n)
+	if len(child.entries) == 1 && child.entries[0].child == nil {
+		// Pull up the last remaining entry of the child to keep the trie
+		// as shallow as possible.
+		n.entries[i] = child.entries[0]
+	} else {
+		n.entries[i].child = child
+	}
+	return n, true
+}
+
+// remove removes the entry at index `i` from `n` and clears `bit` from its
+// bitmap, returning nil if `n` becomes empty.
+func (b *MapBuilder[K, V]) remove(n *hnode[K, V], i int, bit uint32) *hnode[K, V] {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	n = b.editable(n)
+	copy(n.entries[i:], n.entries[i+1:])
+	n.entries[len(n.entries)-1] = hentry[K, V]{}
+	n.entries = n.entries[:len(n.entries)-1]
+	n.bitmap &^= bit
+	return n
+}
@@ -0,0 +1,108 @@
+package persistent_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/maps"
+	"github.com/maargenton/go-generics/pkg/persistent"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestMapZeroValue(t *testing.T) {
+	var m persistent.Map[string, int]
+	var _, ok = m.Get("a")
+	require.That(t, ok).IsFalse()
+	require.That(t, m.Len()).Eq(0)
+	require.That(t, m.Delete("a").Len()).Eq(0)
+	require.That(t, m.ToMap()).IsEmpty()
+}
+
+func TestMapIsPersistent(t *testing.T) {
+	var m1 = persistent.MapOf(map[string]int{"a": 1, "b": 2})
+	var m2 = m1.Set("c", 3).Set("a", 10)
+	var m3 = m2.Delete("b")
+
+	require.That(t, m1.ToMap()).Eq(map[string]int{"a": 1, "b": 2})
+	require.That(t, m2.ToMap()).Eq(map[string]int{"a": 10, "b": 2, "c": 3})
+	require.That(t, m3.ToMap()).Eq(map[string]int{"a": 10, "c": 3})
+	require.That(t, m3.Contains("b")).IsFalse()
+	require.That(t, m3.Keys()).IsEqualSet([]string{"a", "c"})
+}
+
+func TestMapAllStopsEarly(t *testing.T) {
+	var m persistent.Map[int, int]
+	for i := 0; i < 1000; i++ {
+		m = m.Set(i, i)
+	}
+	var n = 0
+	for range m.All() {
+		n++
+		if n == 10 {
+			break
+		}
+	}
+	require.That(t, n).Eq(10)
+}
+
+func TestMapBuilder(t *testing.T) {
+	var b persistent.MapBuilder[int, int]
+	for i := 0; i < 1000; i++ {
+		b.Set(i, i)
+	}
+	var m1 = b.Map()
+	for i := 0; i < 1000; i += 2 {
+		require.That(t, b.Delete(i)).IsTrue()
+	}
+	require.That(t, b.Delete(0)).IsFalse()
+	b.Set(1, -1)
+	var m2 = b.Map()
+
+	require.That(t, m1.Len()).Eq(1000)
+	require.That(t, m2.Len()).Eq(500)
+	var v, _ = m1.Get(1)
+	require.That(t, v).Eq(1)
+	v, _ = b.Get(1)
+	require.That(t, v).Eq(-1)
+}
+
+func TestMapWithMapsFunctions(t *testing.T) {
+	var m = persistent.MapOf(map[string]int{"a": 1, "b": 2, "c": 3})
+	var odd = maps.Filter(m.ToMap(), func(k string, v int) bool { return v%2 == 1 })
+	require.That(t, odd).Eq(map[string]int{"a": 1, "c": 3})
+}
+
+// TestMapMatchesNativeMap applies random operations to a persistent map and a
+// native map, and checks that all versions produced so far still match their
+// reference map.
+func TestMapMatchesNativeMap(t *testing.T) {
+	var r = rand.New(rand.NewPCG(1, 2))
+	var m persistent.Map[int, int]
+	var ref = map[int]int{}
+	var versions []persistent.Map[int, int]
+	var refs []map[int]int
+
+	for step := 0; step < 20000; step++ {
+		var k = r.IntN(2000)
+		if r.IntN(3) == 0 {
+			m = m.Delete(k)
+			delete(ref, k)
+		} else {
+			m = m.Set(k, step)
+			ref[k] = step
+		}
+		require.That(t, m.Len()).Eq(len(ref))
+		if step%1000 == 0 {
+			versions = append(versions, m)
+			refs = append(refs, maps.Filter(ref, func(int, int) bool { return true }))
+		}
+	}
+	for i := range versions {
+		require.That(t, versions[i].ToMap()).Eq(refs[i])
+		for k, v := range refs[i] {
+			var vv, ok = versions[i].Get(k)
+			require.That(t, ok).IsTrue()
+			require.That(t, vv).Eq(v)
+		}
+	}
+}
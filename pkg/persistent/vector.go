@@ -0,0 +1,330 @@
+// Package persistent provides immutable containers where every update returns
+// a new version sharing most of its structure with the previous one, making
+// updates and snapshots cheap compared to copying the whole content. Builders
+// allow batches of updates to be applied in place before producing a new
+// immutable version.
+package persistent
+
+import "iter"
+
+const (
+	branchBits  = 5
+	branchWidth = 1 << branchBits
+	branchMask  = branchWidth - 1
+)
+
+// owner identifies the builder allowed to modify nodes in place. Nodes created
+// by a builder are tagged with its owner; all other nodes are copied before
+// being modified.
+type owner struct{ _ int }
+
+// Vector is an immutable indexed sequence of values, stored as a 32-way trie
+// with a separate tail for efficient appends. Lookups and updates run in
+// O(log32 n), which is effectively constant. The zero value is an empty
+// vector, ready to use.
+type Vector[T any] struct {
+	count int
+	shift uint
+	root  *vnode[T]
+	tail  []T
+}
+
+type vnode[T any] struct {
+	edit     *owner
+	children []*vnode[T]
+	values   []T
+}
+
+// VectorOf returns a new vector containing the values `v`.
+func VectorOf[T any](v ...T) Vector[T] {
+	var b VectorBuilder[T]
+	for _, x := range v {
+		b.Append(x)
+	}
+	return b.Vector()
+}
+
+// Len returns the number of values in the vector.
+func (v Vector[T]) Len() int {
+	return v.count
+}
+
+// At returns the value at index `i`. It panics if `i` is out of range.
+func (v Vector[T]) At(i int) T {
+	if i < 0 || i >= v.count {
+		panic("persistent: index out of range")
+	}
+	return v.leafFor(i)[i&branchMask]
+}
+
+// Set returns a new vector where the value at index `i` is replaced with `x`.
+// It panics if `i` is out of range.
+func (v Vector[T]) Set(i int, x T) Vector[T] {
+	var b = v.Builder()
+	b.Set(i, x)
+	return b.Vector()
+}
+
+// Append returns a new vector with the values `x` added at the end.
+func (v Vector[T]) Append(x ...T) Vector[T] {
+	var b = v.Builder()
+	for _, xx := range x {
+		b.Append(xx)
+	}
+	return b.Vector()
+}
+
+// Pop returns a new vector without its last value. It panics if the vector is
+// empty.
+func (v Vector[T]) Pop() Vector[T] {
+	var b = v.Builder()
+	b.Pop()
+	return b.Vector()
+}
+
+// Slice returns the content of the vector as a newly allocated slice.
+func (v Vector[T]) Slice() []T {
+	var r = make([]T, 0, v.count)
+	for i := 0; i < v.tailOffset(); i += branchWidth {
+		r = append(r, v.leafFor(i)...)
+	}
+	return append(r, v.tail...)
+}
+
+// All returns an iterator over the values of the vector, in order.
+func (v Vector[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < v.count; i += branchWidth {
+			for _, x := range v.leafFor(i) {
+				if !yield(x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Builder returns a new builder initialized with the content of the vector.
+// The vector itself is not affected by changes made through the builder.
+func (v Vector[T]) Builder() *VectorBuilder[T] {
+	return &VectorBuilder[T]{v: v}
+}
+
+func (v Vector[T]) tailOffset() int {
+	return v.count - len(v.tail)
+}
+
+// leafFor returns the values of the leaf, or tail, containing index `i`.
+func (v Vector[T]) leafFor(i int) []T {
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+	var n = v.root
+	for s := v.shift; s > 0; s -= branchBits {
+		n = n.children[(i>>s)&branchMask]
+	}
+	return n.values
+}
+
+// ---------------------------------------------------------------------------
+// VectorBuilder
+
+// VectorBuilder applies a batch of updates to a vector in place, only copying
+// the parts of the structure shared with previously produced vectors. The
+// zero value is an empty builder, ready to use. A builder must not be copied
+// or used concurrently.
+type VectorBuilder[T any] struct {
+	v       Vector[T]
+	edit    *owner
+	ownTail bool
+}
+
+// Len returns the number of values in the builder.
+func (b *VectorBuilder[T]) Len() int {
+	return b.v.count
+}
+
+// At returns the value at index `i`. It panics if `i` is out of range.
+func (b *VectorBuilder[T]) At(i int) T {
+	return b.v.At(i)
+}
+
+// Append adds `x` at the end of the builder content.
+func (b *VectorBuilder[T]) Append(x T) {
+	var v = &b.v
+	if len(v.tail) == branchWidth {
+		var leaf = &vnode[T]{values: v.tail}
+		if b.ownTail {
+			leaf.edit = b.owner()
+		}
+		b.pushLeaf(leaf)
+		v.tail = nil
+	}
+	b.editableTail()
+	v.tail = append(v.tail, x)
+	v.count++
+}
+
+// Set replaces the value at index `i` with `x`. It panics if `i` is out of
+// range.
+func (b *VectorBuilder[T]) Set(i int, x T) {
+	var v = &b.v
+	if i < 0 || i >= v.count {
+		panic("persistent: index out of range")
+	}
+	if i >= v.tailOffset() {
+		b.editableTail()
+		v.tail[i-v.tailOffset()] = x
+		return
+	}
+	v.root = b.set(v.shift, v.root, i, x)
+}
+
+// Pop removes the last value. It panics if the builder is empty.
+func (b *VectorBuilder[T]) Pop() {
+	var v = &b.v
+	switch {
+	case v.count == 0:
+		panic("persistent: pop from empty vector")
+	case v.count == 1:
+		*v = Vector[T]{}
+		b.ownTail = false
+		return
+	case len(v.tail) > 1:
+		var zero T
+		if b.ownTail {
+			v.tail[len(v.tail)-1] = zero
+		}
+		v.tail = v.tail[:len(v.tail)-1]
+		v.count--
+		return
+	}
+
+	v.tail = v.leafFor(v.count - 2)
+	b.ownTail = false
+	if v.shift == 0 {
+		v.root = nil
+	} else {
+		v.root = b.popLeaf(v.shift, v.root)
+		for v.shift > 0 && len(v.root.children) == 1 {
+			v.root = v.root.children[0]
+			v.shift -= branchBits
+		}
+	}
+	v.count--
+}
+
+// Vector returns an immutable vector with the current content of the builder.
+// The builder remains usable, and further changes do not affect the returned
+// vector.
+func (b *VectorBuilder[T]) Vector() Vector[T] {
+	b.edit = nil
+	b.ownTail = false
+	return b.v
+}
+
+func (b *VectorBuilder[T]) owner() *owner {
+	if b.edit == nil {
+		b.edit = &owner{}
+	}
+	return b.edit
+}
+
+func (b *VectorBuilder[T]) editable(n *vnode[T]) *vnode[T] {
+	if n.edit != nil && n.edit == b.edit {
+		return n
+	}
+	var c = &vnode[T]{edit: b.owner()}
+	if n.children != nil {
+		c.children = append(make([]*vnode[T], 0, branchWidth), n.children...)
+	} else {
+		c.values = append([]T(nil), n.values...)
+	}
+	return c
+}
+
+func (b *VectorBuilder[T]) editableTail() {
+	if !b.ownTail {
+		b.v.tail = append(make([]T, 0, branchWidth), b.v.tail...)
+		b.ownTail = true
+	}
+}
+
+// pushLeaf inserts a full leaf at the end of the trie, adding a level to the
+// trie if it is full.
+func (b *VectorBuilder[T]) pushLeaf(leaf *vnode[T]) {
+	var v = &b.v
+	var i = v.tailOffset()
+	switch {
+	case v.root == nil:
+		v.root = leaf
+		v.shift = 0
+	case i == 1<<(v.shift+branchBits):
+		v.root = &vnode[T]{
+			edit:     b.owner(),
+			children: []*vnode[T]{v.root, b.newPath(v.shift, leaf)},
+		}
+		v.shift += branchBits
+	default:
+		v.root = b.pushTail(v.shift, v.root, leaf, i)
+	}
+}
+
+func (b *VectorBuilder[T]) pushTail(shift uint, n, leaf *vnode[T], i int) *vnode[T] {
+	n = b.editable(n)
+	var sub = (i >> shift) & branchMask
+	if sub < len(n.children) {
+		n.children[sub] = b.pushTail(shift-branchBits, n.children[sub], leaf, i)
+	} else {
+		n.children = append(n.children, b.newPath(shift-branchBits, leaf))
+	}
+	return n
+}
+
+func (b *VectorBuilder[T]) newPath(shift uint, leaf *vnode[T]) *vnode[T] {
+	if shift == 0 {
+		return leaf
+	}
+	return &vnode[T]{
+		edit:     b.owner(),
+		children: []*vnode[T]{b.newPath(shift-branchBits, leaf)},
+	}
+}
+
+func (b *VectorBuilder[T]) set(shift uint, n *vnode[T], i int, x T) *vnode[T] {
+	n = b.editable(n)
+	if shift == 0 {
+		n.values[i&branchMask] = x
+	} else {
+		var sub = (i >> shift) & branchMask
+		n.children[sub] = b.set(shift-branchBits, n.children[sub], i, x)
+	}
+	return n
+}
+
+// popLeaf removes the last leaf of the trie, returning nil if `n` becomes
+// empty.
+func (b *VectorBuilder[T]) popLeaf(shift uint, n *vnode[T]) *vnode[T] {
+	var sub = ((b.v.count - 2) >> shift) & branchMask
+	if shift > branchBits {
+		var child = b.popLeaf(shift-branchBits, n.children[sub])
+		if child == nil && sub == 0 {
+			return nil
+		}
+		n = b.editable(n)
+		if child == nil {
+			n.children[sub] = nil
+			n.children = n.children[:sub]
+		} else {
+			n.children[sub] = child
+		}
+		return n
+	}
+	if sub == 0 {
+		return nil
+	}
+	n = b.editable(n)
+	n.children[sub] = nil
+	n.children = n.children[:sub]
+	return n
+}
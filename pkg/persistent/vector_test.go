@@ -0,0 +1,125 @@
+package persistent_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/persistent"
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestVectorZeroValue(t *testing.T) {
+	var v persistent.Vector[int]
+	require.That(t, v.Len()).Eq(0)
+	require.That(t, v.Slice()).IsEmpty()
+	require.That(t, func() { v.Pop() }).
+		PanicsAndRecoveredValue().Eq("persistent: pop from empty vector")
+	require.That(t, func() { v.At(0) }).
+		PanicsAndRecoveredValue().Eq("persistent: index out of range")
+}
+
+func TestVectorAppend(t *testing.T) {
+	for _, n := range []int{1, 31, 32, 33, 1024, 1056, 1057, 32*32*32 + 33} {
+		var v = persistent.VectorOf(slices.Range(0, n, 1)...)
+		require.That(t, v.Len()).Eq(n)
+		require.That(t, v.Slice()).Eq(slices.Range(0, n, 1))
+		require.That(t, v.At(n-1)).Eq(n - 1)
+	}
+}
+
+func TestVectorIsPersistent(t *testing.T) {
+	var v1 = persistent.VectorOf(slices.Range(0, 100, 1)...)
+	var v2 = v1.Set(10, -1).Set(99, -2)
+	var v3 = v1.Append(100)
+	var v4 = v1.Pop().Pop()
+
+	require.That(t, v1.Slice()).Eq(slices.Range(0, 100, 1))
+	require.That(t, v2.At(10)).Eq(-1)
+	require.That(t, v2.At(99)).Eq(-2)
+	require.That(t, v2.At(11)).Eq(11)
+	require.That(t, v3.Slice()).Eq(slices.Range(0, 101, 1))
+	require.That(t, v4.Slice()).Eq(slices.Range(0, 98, 1))
+}
+
+func TestVectorAll(t *testing.T) {
+	var v = persistent.VectorOf(slices.Range(0, 100, 1)...)
+	var r []int
+	for x := range v.All() {
+		r = append(r, x)
+	}
+	require.That(t, r).Eq(slices.Range(0, 100, 1))
+
+	r = nil
+	for x := range v.All() {
+		if x == 40 {
+			break
+		}
+		r = append(r, x)
+	}
+	require.That(t, r).Eq(slices.Range(0, 40, 1))
+}
+
+func TestVectorBuilder(t *testing.T) {
+	var v1 = persistent.VectorOf(slices.Range(0, 100, 1)...)
+	var b = v1.Builder()
+	for i := 0; i < 100; i++ {
+		b.Set(i, b.At(i)*2)
+	}
+	b.Append(200)
+	var v2 = b.Vector()
+	b.Set(0, -1)
+	b.Pop()
+	var v3 = b.Vector()
+
+	require.That(t, v1.Slice()).Eq(slices.Range(0, 100, 1))
+	require.That(t, v2.Len()).Eq(101)
+	require.That(t, v2.At(0)).Eq(0)
+	require.That(t, v2.At(50)).Eq(100)
+	require.That(t, v2.At(100)).Eq(200)
+	require.That(t, v3.Len()).Eq(100)
+	require.That(t, v3.At(0)).Eq(-1)
+	require.That(t, b.Len()).Eq(100)
+}
+
+// TestVectorMatchesSlice applies random operations to a vector and a slice,
+// and checks after each step that all versions of the vector produced so far
+// still match their reference slice.
+func TestVectorMatchesSlice(t *testing.T) {
+	var r = rand.New(rand.NewPCG(1, 2))
+	var v persistent.Vector[int]
+	var ref []int
+	var versions []persistent.Vector[int]
+	var refs [][]int
+
+	for step := 0; step < 2000; step++ {
+		switch op := r.IntN(10); {
+		case op < 6:
+			var n = r.IntN(70)
+			var x = slices.Range(step*100, step*100+n, 1)
+			v = v.Append(x...)
+			ref = append(append([]int(nil), ref...), x...)
+		case op < 8 && len(ref) > 0:
+			var i = r.IntN(len(ref))
+			v = v.Set(i, -step)
+			ref = append([]int(nil), ref...)
+			ref[i] = -step
+		case len(ref) > 0:
+			var n = r.IntN(min(len(ref), 70)) + 1
+			var b = v.Builder()
+			for i := 0; i < n; i++ {
+				b.Pop()
+			}
+			v = b.Vector()
+			ref = ref[:len(ref)-n]
+		}
+		if step%100 == 0 {
+			versions = append(versions, v)
+			refs = append(refs, ref)
+		}
+		require.That(t, v.Len()).Eq(len(ref))
+	}
+	for i := range versions {
+		require.That(t, versions[i].Slice()).Eq(refs[i])
+	}
+}
@@ -0,0 +1,54 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/ring"
+	"github.com/maargenton/go-generics/pkg/slices"
+)
+
+func makeInput(n int) []int {
+	var v = make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		v = append(v, (i*7919)%1000)
+	}
+	return v
+}
+
+func BenchmarkRingPush(b *testing.B) {
+	var r = ring.New[int](100, ring.Overwrite)
+	for n := 0; n < b.N; n++ {
+		r.Push(n)
+	}
+}
+
+func BenchmarkDequePushPop(b *testing.B) {
+	var d ring.Deque[int]
+	for n := 0; n < b.N; n++ {
+		d.PushBack(n)
+		if d.Len() > 100 {
+			d.PopFront()
+		}
+	}
+}
+
+func BenchmarkRollingMax10K(b *testing.B) {
+	var v = makeInput(10000)
+	for n := 0; n < b.N; n++ {
+		ring.RollingMax(v, 100)
+	}
+}
+
+func BenchmarkRollingMaxNaive10K(b *testing.B) {
+	var v = makeInput(10000)
+	for n := 0; n < b.N; n++ {
+		slices.MapCons(v, 100, slices.Max[int])
+	}
+}
+
+func BenchmarkRollingSum10K(b *testing.B) {
+	var v = makeInput(10000)
+	for n := 0; n < b.N; n++ {
+		ring.RollingSum(v, 100)
+	}
+}
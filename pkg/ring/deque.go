@@ -0,0 +1,157 @@
+package ring
+
+import "iter"
+
+// Deque is a double-ended queue backed by a growable circular buffer. Pushing
+// and popping at either end is amortized O(1). The zero value is an empty
+// deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	len  int
+}
+
+// NewDeque creates a new empty deque with storage pre-allocated for
+// `capacity` elements.
+func NewDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{buf: make([]T, capacity)}
+}
+
+// Len returns the number of elements in the deque.
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// PushBack appends `v` at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.wrap(d.head+d.len)] = v
+	d.len++
+}
+
+// PushFront inserts `v` at the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.wrap(d.head + len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopBack removes and returns the element at the back of the deque. The
+// second return value is false if the deque is empty.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	var zero T
+	var i = d.wrap(d.head + d.len - 1)
+	v = d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	return v, true
+}
+
+// PopFront removes and returns the element at the front of the deque. The
+// second return value is false if the deque is empty.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	var zero T
+	v = d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.wrap(d.head + 1)
+	d.len--
+	return v, true
+}
+
+// Front returns the element at the front of the deque without removing it.
+// The second return value is false if the deque is empty.
+func (d *Deque[T]) Front() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the element at the back of the deque without removing it. The
+// second return value is false if the deque is empty.
+func (d *Deque[T]) Back() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	return d.buf[d.wrap(d.head+d.len-1)], true
+}
+
+// At returns the i-th element of the deque, from front to back. It panics if
+// `i` is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.len {
+		panic("ring: index out of range")
+	}
+	return d.buf[d.wrap(d.head+i)]
+}
+
+// Reset removes all the elements from the deque, preserving its storage.
+func (d *Deque[T]) Reset() {
+	clear(d.buf)
+	d.head = 0
+	d.len = 0
+}
+
+// Slice returns a copy of the content of the deque, from front to back. The
+// result does not alias the deque storage and can be used with any function
+// of the `slices` package.
+func (d *Deque[T]) Slice() []T {
+	var s = make([]T, 0, d.len)
+	var tail = d.head + d.len
+	if tail <= len(d.buf) {
+		return append(s, d.buf[d.head:tail]...)
+	}
+	s = append(s, d.buf[d.head:]...)
+	return append(s, d.buf[:tail-len(d.buf)]...)
+}
+
+// All returns an iterator over the elements of the deque, from front to back.
+// The deque must not be modified during iteration.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.len; i++ {
+			if !yield(d.buf[d.wrap(d.head+i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements of the deque, from back to
+// front. The deque must not be modified during iteration.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.len - 1; i >= 0; i-- {
+			if !yield(d.buf[d.wrap(d.head+i)]) {
+				return
+			}
+		}
+	}
+}
+
+// grow doubles the size of the underlying storage when full, moving the
+// existing elements to the beginning of the new buffer.
+func (d *Deque[T]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	var buf = make([]T, max(2*len(d.buf), 8))
+	var n = copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *Deque[T]) wrap(i int) int {
+	if i >= len(d.buf) {
+		i -= len(d.buf)
+	}
+	return i
+}
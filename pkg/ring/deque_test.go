@@ -0,0 +1,53 @@
+package ring_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/ring"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestDequePushPop(t *testing.T) {
+	var d ring.Deque[int]
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+	require.That(t, d.Len()).Eq(20)
+	require.That(t, d.Slice()).Eq([]int{
+		-10, -9, -8, -7, -6, -5, -4, -3, -2, -1,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+	})
+
+	var front, _ = d.PopFront()
+	var back, _ = d.PopBack()
+	require.That(t, front).Eq(-10)
+	require.That(t, back).Eq(9)
+	require.That(t, d.At(0)).Eq(-9)
+}
+
+func TestDequeEmpty(t *testing.T) {
+	var d = ring.NewDeque[int](4)
+	var _, ok = d.PopFront()
+	require.That(t, ok).IsFalse()
+	_, ok = d.PopBack()
+	require.That(t, ok).IsFalse()
+	_, ok = d.Front()
+	require.That(t, ok).IsFalse()
+	_, ok = d.Back()
+	require.That(t, ok).IsFalse()
+}
+
+func TestDequeWrapAround(t *testing.T) {
+	var d = ring.NewDeque[int](4)
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+		if d.Len() > 3 {
+			d.PopFront()
+		}
+	}
+	require.That(t, d.Slice()).Eq([]int{97, 98, 99})
+	require.That(t, slices.Collect(d.All())).Eq([]int{97, 98, 99})
+	require.That(t, slices.Collect(d.Backward())).Eq([]int{99, 98, 97})
+}
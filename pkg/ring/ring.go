@@ -0,0 +1,149 @@
+package ring
+
+import "iter"
+
+// Policy defines the behavior of a Ring when pushing a new element while the
+// ring is already full.
+type Policy int
+
+const (
+	// Overwrite discards the oldest element to make room for the new one.
+	Overwrite Policy = iota
+
+	// Reject discards the new element and leaves the ring unchanged.
+	Reject
+)
+
+// Ring is a fixed-capacity FIFO buffer. Once full, pushing a new element
+// either overwrites the oldest one or is rejected, depending on the policy
+// selected at construction.
+type Ring[T any] struct {
+	buf    []T
+	head   int
+	len    int
+	policy Policy
+}
+
+// New creates a new ring with the given capacity and policy. It panics if
+// `capacity` is not strictly positive.
+func New[T any](capacity int, policy Policy) *Ring[T] {
+	if capacity <= 0 {
+		panic("ring: capacity must be strictly positive")
+	}
+	return &Ring[T]{
+		buf:    make([]T, capacity),
+		policy: policy,
+	}
+}
+
+// Len returns the number of elements currently stored in the ring.
+func (r *Ring[T]) Len() int {
+	return r.len
+}
+
+// Cap returns the maximum number of elements the ring can hold.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Full returns true if the ring holds as many elements as its capacity.
+func (r *Ring[T]) Full() bool {
+	return r.len == len(r.buf)
+}
+
+// Push appends `v` after the most recent element of the ring. If the ring is
+// full, the outcome depends on the ring policy; the function returns false
+// only if the element was rejected.
+func (r *Ring[T]) Push(v T) bool {
+	if r.len == len(r.buf) {
+		if r.policy == Reject {
+			return false
+		}
+		r.buf[r.head] = v
+		r.head = r.wrap(r.head + 1)
+		return true
+	}
+	r.buf[r.wrap(r.head+r.len)] = v
+	r.len++
+	return true
+}
+
+// Pop removes and returns the oldest element of the ring. The second return
+// value is false if the ring is empty.
+func (r *Ring[T]) Pop() (v T, ok bool) {
+	if r.len == 0 {
+		return v, false
+	}
+	var zero T
+	v = r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = r.wrap(r.head + 1)
+	r.len--
+	return v, true
+}
+
+// Oldest returns the oldest element of the ring without removing it. The
+// second return value is false if the ring is empty.
+func (r *Ring[T]) Oldest() (v T, ok bool) {
+	if r.len == 0 {
+		return v, false
+	}
+	return r.buf[r.head], true
+}
+
+// Newest returns the most recently pushed element of the ring. The second
+// return value is false if the ring is empty.
+func (r *Ring[T]) Newest() (v T, ok bool) {
+	if r.len == 0 {
+		return v, false
+	}
+	return r.buf[r.wrap(r.head+r.len-1)], true
+}
+
+// At returns the i-th element of the ring, from oldest to newest. It panics if
+// `i` is out of range.
+func (r *Ring[T]) At(i int) T {
+	if i < 0 || i >= r.len {
+		panic("ring: index out of range")
+	}
+	return r.buf[r.wrap(r.head+i)]
+}
+
+// Reset removes all the elements from the ring, preserving its capacity.
+func (r *Ring[T]) Reset() {
+	clear(r.buf)
+	r.head = 0
+	r.len = 0
+}
+
+// Slice returns a copy of the content of the ring, from oldest to newest. The
+// result does not alias the ring storage and can be used with any function of
+// the `slices` package.
+func (r *Ring[T]) Slice() []T {
+	var s = make([]T, 0, r.len)
+	var tail = r.head + r.len
+	if tail <= len(r.buf) {
+		return append(s, r.buf[r.head:tail]...)
+	}
+	s = append(s, r.buf[r.head:]...)
+	return append(s, r.buf[:tail-len(r.buf)]...)
+}
+
+// All returns an iterator over the elements of the ring, from oldest to
+// newest. The ring must not be modified during iteration.
+func (r *Ring[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.len; i++ {
+			if !yield(r.buf[r.wrap(r.head+i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (r *Ring[T]) wrap(i int) int {
+	if i >= len(r.buf) {
+		i -= len(r.buf)
+	}
+	return i
+}
@@ -0,0 +1,83 @@
+package ring_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/ring"
+	gslices "github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestRingOverwrite(t *testing.T) {
+	var r = ring.New[int](3, ring.Overwrite)
+	for i := 0; i < 5; i++ {
+		require.That(t, r.Push(i)).IsTrue()
+	}
+	require.That(t, r.Len()).Eq(3)
+	require.That(t, r.Full()).IsTrue()
+	require.That(t, r.Slice()).Eq([]int{2, 3, 4})
+	require.That(t, r.At(0)).Eq(2)
+
+	var oldest, _ = r.Oldest()
+	var newest, _ = r.Newest()
+	require.That(t, oldest).Eq(2)
+	require.That(t, newest).Eq(4)
+}
+
+func TestRingReject(t *testing.T) {
+	var r = ring.New[int](3, ring.Reject)
+	for i := 0; i < 3; i++ {
+		require.That(t, r.Push(i)).IsTrue()
+	}
+	require.That(t, r.Push(3)).IsFalse()
+	require.That(t, r.Slice()).Eq([]int{0, 1, 2})
+
+	var v, ok = r.Pop()
+	require.That(t, v).Eq(0)
+	require.That(t, ok).IsTrue()
+	require.That(t, r.Push(3)).IsTrue()
+	require.That(t, r.Slice()).Eq([]int{1, 2, 3})
+}
+
+func TestRingPopEmpty(t *testing.T) {
+	var r = ring.New[int](2, ring.Overwrite)
+	var _, ok = r.Pop()
+	require.That(t, ok).IsFalse()
+
+	r.Push(1)
+	r.Reset()
+	require.That(t, r.Len()).Eq(0)
+	require.That(t, r.Slice()).Eq([]int{})
+}
+
+func TestRingSliceWithSlicesPackage(t *testing.T) {
+	var r = ring.New[int](4, ring.Overwrite)
+	for i := 0; i < 6; i++ {
+		r.Push(i)
+	}
+	var s = r.Slice()
+	require.That(t, gslices.Cons(s, 2)).Eq([][]int{{2, 3}, {3, 4}, {4, 5}})
+
+	s[0] = -1
+	require.That(t, r.At(0)).Eq(2)
+}
+
+func TestRingAll(t *testing.T) {
+	var r = ring.New[int](3, ring.Overwrite)
+	for i := 0; i < 4; i++ {
+		r.Push(i)
+	}
+	require.That(t, slices.Collect(r.All())).Eq([]int{1, 2, 3})
+
+	var first []int
+	for v := range r.All() {
+		first = append(first, v)
+		break
+	}
+	require.That(t, first).Eq([]int{1})
+}
+
+func TestNewRingPanicsOnInvalidCapacity(t *testing.T) {
+	require.That(t, func() { ring.New[int](0, ring.Overwrite) }).Panics()
+}
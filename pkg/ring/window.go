@@ -0,0 +1,154 @@
+package ring
+
+import "github.com/maargenton/go-generics/pkg/slices"
+
+// Window maintains a rolling window over the last `n` values of a stream and
+// provides the sum, minimum and maximum of the window in amortized O(1) per
+// pushed value. Minimum and maximum are tracked with monotonic deques.
+type Window[T slices.Number] struct {
+	values *Ring[T]
+	mins   Deque[indexed[T]]
+	maxs   Deque[indexed[T]]
+	sum    T
+	seq    int
+}
+
+type indexed[T any] struct {
+	seq int
+	v   T
+}
+
+// NewWindow creates a new rolling window over the last `n` pushed values. It
+// panics if `n` is not strictly positive.
+func NewWindow[T slices.Number](n int) *Window[T] {
+	mustBeValidWindow(n)
+	return &Window[T]{values: New[T](n, Overwrite)}
+}
+
+// Push adds `v` to the window, evicting the oldest value if the window is
+// already full.
+func (w *Window[T]) Push(v T) {
+	if w.values.Full() {
+		var old, _ = w.values.Oldest()
+		w.sum -= old
+	}
+	w.values.Push(v)
+	w.sum += v
+
+	var first = w.seq - w.values.Len() + 1
+	for e, ok := w.mins.Back(); ok && e.v >= v; e, ok = w.mins.Back() {
+		w.mins.PopBack()
+	}
+	w.mins.PushBack(indexed[T]{w.seq, v})
+	for e, _ := w.mins.Front(); e.seq < first; e, _ = w.mins.Front() {
+		w.mins.PopFront()
+	}
+
+	for e, ok := w.maxs.Back(); ok && e.v <= v; e, ok = w.maxs.Back() {
+		w.maxs.PopBack()
+	}
+	w.maxs.PushBack(indexed[T]{w.seq, v})
+	for e, _ := w.maxs.Front(); e.seq < first; e, _ = w.maxs.Front() {
+		w.maxs.PopFront()
+	}
+	w.seq++
+}
+
+// Len returns the number of values currently in the window.
+func (w *Window[T]) Len() int {
+	return w.values.Len()
+}
+
+// Full returns true once `n` values have been pushed into the window.
+func (w *Window[T]) Full() bool {
+	return w.values.Full()
+}
+
+// Slice returns a copy of the values currently in the window, from oldest to
+// newest.
+func (w *Window[T]) Slice() []T {
+	return w.values.Slice()
+}
+
+// Sum returns the sum of the values currently in the window. For
+// floating-point types, the running sum is subject to accumulated rounding
+// errors.
+func (w *Window[T]) Sum() T {
+	return w.sum
+}
+
+// Min returns the minimum value currently in the window, or zero if the window
+// is empty.
+func (w *Window[T]) Min() T {
+	var e, _ = w.mins.Front()
+	return e.v
+}
+
+// Max returns the maximum value currently in the window, or zero if the window
+// is empty.
+func (w *Window[T]) Max() T {
+	var e, _ = w.maxs.Front()
+	return e.v
+}
+
+// RollingSum returns the sum of each successive overlapping n-tuple of `v`, as
+// would be returned by `slices.Cons(v, n)`. The result is empty if the input
+// is shorter than `n`. It panics if `n` is not strictly positive.
+func RollingSum[T slices.Number](v []T, n int) []T {
+	mustBeValidWindow(n)
+	var r = make([]T, 0, max(len(v)-n+1, 0))
+	var sum T
+	for i, a := range v {
+		sum += a
+		if i >= n {
+			sum -= v[i-n]
+		}
+		if i >= n-1 {
+			r = append(r, sum)
+		}
+	}
+	return r
+}
+
+// RollingMin returns the minimum of each successive overlapping n-tuple of
+// `v`, as would be returned by `slices.Cons(v, n)`. The result is empty if the
+// input is shorter than `n`. It panics if `n` is not strictly positive.
+func RollingMin[T slices.Number](v []T, n int) []T {
+	return monotonic(v, n, func(a, b T) bool { return a >= b })
+}
+
+// RollingMax returns the maximum of each successive overlapping n-tuple of
+// `v`, as would be returned by `slices.Cons(v, n)`. The result is empty if the
+// input is shorter than `n`. It panics if `n` is not strictly positive.
+func RollingMax[T slices.Number](v []T, n int) []T {
+	return monotonic(v, n, func(a, b T) bool { return a <= b })
+}
+
+// monotonic maintains a deque of indices into `v` whose values are monotonic
+// according to `dominated`; the front of the deque is the aggregate of the
+// current window.
+func monotonic[T slices.Number](v []T, n int, dominated func(a, b T) bool) []T {
+	mustBeValidWindow(n)
+	var r = make([]T, 0, max(len(v)-n+1, 0))
+	var d = NewDeque[int](n)
+	for i, a := range v {
+		for j, ok := d.Back(); ok && dominated(v[j], a); j, ok = d.Back() {
+			d.PopBack()
+		}
+		d.PushBack(i)
+		if j, _ := d.Front(); j <= i-n {
+			d.PopFront()
+		}
+		if i >= n-1 {
+			var j, _ = d.Front()
+			r = append(r, v[j])
+		}
+	}
+	return r
+}
+
+func mustBeValidWindow(n int) {
+	if n <= 0 {
+		panic("ring: window size must be positive")
+	}
+}
@@ -0,0 +1,48 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/ring"
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestWindow(t *testing.T) {
+	var w = ring.NewWindow[int](3)
+	var sums, mins, maxs []int
+	for _, v := range []int{5, 1, 4, 2, 8, 3} {
+		w.Push(v)
+		sums = append(sums, w.Sum())
+		mins = append(mins, w.Min())
+		maxs = append(maxs, w.Max())
+	}
+	require.That(t, sums).Eq([]int{5, 6, 10, 7, 14, 13})
+	require.That(t, mins).Eq([]int{5, 1, 1, 1, 2, 2})
+	require.That(t, maxs).Eq([]int{5, 5, 5, 4, 8, 8})
+	require.That(t, w.Slice()).Eq([]int{2, 8, 3})
+}
+
+func TestRollingAggregates(t *testing.T) {
+	var v = []int{5, 1, 4, 2, 8, 3, 3, 7}
+	var sum = func(a []int) int { return slices.Reduce(a, 0, func(a, m int) int { return a + m }) }
+
+	require.That(t, ring.RollingSum(v, 3)).Eq(slices.MapCons(v, 3, sum))
+	require.That(t, ring.RollingMin(v, 3)).Eq(slices.MapCons(v, 3, slices.Min[int]))
+	require.That(t, ring.RollingMax(v, 3)).Eq(slices.MapCons(v, 3, slices.Max[int]))
+	require.That(t, ring.RollingMax(v, 10)).Eq([]int{})
+}
+
+func TestRollingAggregatesPanicOnInvalidWindow(t *testing.T) {
+	var v = []int{5, 1, 4}
+	for _, n := range []int{0, -1} {
+		require.That(t, func() { ring.RollingSum(v, n) }).
+			PanicsAndRecoveredValue().Eq("ring: window size must be positive")
+		require.That(t, func() { ring.RollingMin(v, n) }).
+			PanicsAndRecoveredValue().Eq("ring: window size must be positive")
+		require.That(t, func() { ring.RollingMax(v, n) }).
+			PanicsAndRecoveredValue().Eq("ring: window size must be positive")
+		require.That(t, func() { ring.NewWindow[int](n) }).
+			PanicsAndRecoveredValue().Eq("ring: window size must be positive")
+	}
+}
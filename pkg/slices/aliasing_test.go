@@ -0,0 +1,70 @@
+package slices_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// windowFunctions lists the window-based functions returning sub-slices that
+// alias their input.
+var windowFunctions = map[string]func(v []int) [][]int{
+	"Cons":    func(v []int) [][]int { return slices.Cons(v, 3) },
+	"Slice":   func(v []int) [][]int { return slices.Slice(v, 3) },
+	"Windows": func(v []int) [][]int { return slices.Windows(v, 3, 2, slices.KeepPartial, 0) },
+	"SliceBetween": func(v []int) [][]int {
+		return slices.SliceBetween(v, func(a, b int) bool { return b%3 == 0 })
+	},
+	"SliceBy": func(v []int) [][]int {
+		return slices.SliceBy(v, func(a int) int { return a / 3 })
+	},
+	"GroupConsecutive": func(v []int) [][]int {
+		var groups = slices.GroupConsecutive(v, func(a int) int { return a / 3 })
+		return slices.Map(groups, func(g slices.Group[int, int]) []int { return g.Values })
+	},
+}
+
+func TestWindowAppendDoesNotLeak(t *testing.T) {
+	for name, f := range windowFunctions {
+		t.Run(name, func(t *testing.T) {
+			var v = makeRange(10)
+			for _, w := range f(v) {
+				require.That(t, w).Capacity().Eq(len(w))
+				_ = append(w, -1)
+			}
+			require.That(t, v).Eq(makeRange(10))
+		})
+	}
+}
+
+func TestWindowWritesAreShared(t *testing.T) {
+	var v = makeRange(10)
+	var w = slices.Cons(v, 3)
+	w[0][2] = -1
+	require.That(t, w[1][1]).Eq(-1)
+	require.That(t, v[2]).Eq(-1)
+}
+
+func TestConsCopy(t *testing.T) {
+	var v = makeRange(5)
+	var w = slices.ConsCopy(v, 3)
+	require.That(t, w).Eq(slices.Cons(makeRange(5), 3))
+
+	w[0][2] = -1
+	w[0] = append(w[0], -2)
+	require.That(t, w[1]).Eq([]int{1, 2, 3})
+	require.That(t, w[2]).Eq([]int{2, 3, 4})
+	require.That(t, v).Eq(makeRange(5))
+}
+
+func TestSliceCopy(t *testing.T) {
+	var v = makeRange(5)
+	var w = slices.SliceCopy(v, 2)
+	require.That(t, w).Eq(slices.Slice(makeRange(5), 2))
+
+	w[0][1] = -1
+	w[0] = append(w[0], -2)
+	require.That(t, w[1]).Eq([]int{2, 3})
+	require.That(t, v).Eq(makeRange(5))
+}
@@ -89,3 +89,37 @@ func BenchmarkFilterMap10K(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkScan10K(b *testing.B) {
+	var v = makeRange(10000)
+	for n := 0; n < b.N; n++ {
+		slices.Scan(v, 0, func(a int, memo int) int {
+			return memo + a
+		})
+	}
+}
+
+func BenchmarkPrefixSum10K(b *testing.B) {
+	var v = makeRange(10000)
+	for n := 0; n < b.N; n++ {
+		slices.PrefixSum(v)
+	}
+}
+
+func BenchmarkJoin10K(b *testing.B) {
+	var v = makeRange(10000)
+	var id = func(v int) int { return v }
+	var half = func(v int) int { return v / 2 }
+	for n := 0; n < b.N; n++ {
+		slices.Join(v, v, id, half)
+	}
+}
+
+func BenchmarkMergeJoin10K(b *testing.B) {
+	var v = makeRange(10000)
+	var id = func(v int) int { return v }
+	var half = func(v int) int { return v / 2 }
+	for n := 0; n < b.N; n++ {
+		slices.MergeJoin(v, v, id, half)
+	}
+}
@@ -0,0 +1,194 @@
+package slices
+
+import "iter"
+
+// Range returns a slice of values starting at `start`, incremented by `step`,
+// and stopping before reaching `end`. `step` can be negative, in which case
+// `end` must be smaller than `start` for the result to be non-empty. Range
+// panics if `step` is zero.
+func Range[T Number](start, end, step T) []T {
+	var r []T
+	if n := rangeLen(start, end, step); n > 0 {
+		r = make([]T, 0, n)
+	}
+	for v := range RangeSeq(start, end, step) {
+		r = append(r, v)
+	}
+	return r
+}
+
+// RangeSeq is the lazy variant of Range().
+func RangeSeq[T Number](start, end, step T) iter.Seq[T] {
+	if step == 0 {
+		panic("slices: Range step must not be zero")
+	}
+	return func(yield func(T) bool) {
+		if (step > 0 && start >= end) || (step < 0 && start <= end) {
+			return
+		}
+		var half = 0.5
+		var float = T(half) != 0
+		var v = start
+		for i := 1; ; i++ {
+			if !yield(v) {
+				return
+			}
+			// Floating-point values are computed from `start` rather than
+			// accumulated to avoid drifting. Integer values are accumulated,
+			// and the loop stops if the next value wraps around, so that
+			// narrow types never overflow.
+			var next = v + step
+			if float {
+				next = start + T(i)*step
+			}
+			if (step > 0 && (next >= end || next <= v)) ||
+				(step < 0 && (next <= end || next >= v)) {
+				return
+			}
+			v = next
+		}
+	}
+}
+
+// Repeat returns a slice containing `n` copies of `v`. It panics if `n` is
+// negative.
+func Repeat[T any](v T, n int) []T {
+	mustNotBeNegative("Repeat", "n", n)
+	var r = make([]T, n)
+	for i := range r {
+		r[i] = v
+	}
+	return r
+}
+
+// RepeatSeq is the lazy variant of Repeat(). If `n` is negative, the sequence
+// is infinite.
+func RepeatSeq[T any](v T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Generate returns a slice of `n` elements, where each element is the result
+// of invoking `f` with its index. It panics if `n` is negative.
+func Generate[T any](n int, f func(i int) T) []T {
+	mustNotBeNegative("Generate", "n", n)
+	var r = make([]T, n)
+	for i := range r {
+		r[i] = f(i)
+	}
+	return r
+}
+
+// GenerateSeq is the lazy variant of Generate(). If `n` is negative, the
+// sequence is infinite.
+func GenerateSeq[T any](n int, f func(i int) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			if !yield(f(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Unfold builds a slice by repeatedly invoking `f` with the current state,
+// starting with `seed`. Each invocation returns the next element, the next
+// state and a boolean that is false once the sequence is complete, in which
+// case the returned element is discarded.
+func Unfold[T, S any](seed S, f func(s S) (T, S, bool)) []T {
+	return collect(UnfoldSeq(seed, f))
+}
+
+// UnfoldSeq is the lazy variant of Unfold(). The sequence is infinite if `f`
+// never returns false.
+func UnfoldSeq[T, S any](seed S, f func(s S) (T, S, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var s = seed
+		for {
+			var v T
+			var ok bool
+			v, s, ok = f(s)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate returns a slice of `n` elements, starting with `seed`, where each
+// subsequent element is the result of invoking `f` with the previous one. It
+// panics if `n` is negative.
+func Iterate[T any](seed T, f func(v T) T, n int) []T {
+	mustNotBeNegative("Iterate", "n", n)
+	var r = make([]T, 0, n)
+	for v := range IterateSeq(seed, f, n) {
+		r = append(r, v)
+	}
+	return r
+}
+
+// IterateSeq is the lazy variant of Iterate(). If `n` is negative, the
+// sequence is infinite.
+func IterateSeq[T any](seed T, f func(v T) T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var v = seed
+		for i := 0; n < 0 || i < n; i++ {
+			if i != 0 {
+				v = f(v)
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Cycle returns a slice containing the elements of `v` repeated `n` times. It
+// panics if `n` is negative.
+func Cycle[T any](v []T, n int) []T {
+	mustNotBeNegative("Cycle", "n", n)
+	var r = make([]T, 0, len(v)*n)
+	for i := 0; i < n; i++ {
+		r = append(r, v...)
+	}
+	return r
+}
+
+// CycleSeq is the lazy variant of Cycle(). If `n` is negative, the sequence is
+// infinite, unless `v` is empty.
+func CycleSeq[T any](v []T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if len(v) == 0 {
+			return
+		}
+		for i := 0; n < 0 || i < n; i++ {
+			for _, a := range v {
+				if !yield(a) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// rangeLen returns an estimate of the number of elements produced by Range(),
+// used only as a capacity hint.
+func rangeLen[T Number](start, end, step T) int {
+	if step == 0 || (step > 0 && start >= end) || (step < 0 && start <= end) {
+		return 0
+	}
+	return int((float64(end)-float64(start))/float64(step)) + 1
+}
+
+func collect[T any](seq iter.Seq[T]) []T {
+	var r []T
+	for v := range seq {
+		r = append(r, v)
+	}
+	return r
+}
@@ -0,0 +1,121 @@
+package slices_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestRange(t *testing.T) {
+	require.That(t, slices.Range(0, 5, 1)).Eq([]int{0, 1, 2, 3, 4})
+	require.That(t, slices.Range(0, 10, 3)).Eq([]int{0, 3, 6, 9})
+	require.That(t, slices.Range(5, 0, -2)).Eq([]int{5, 3, 1})
+	require.That(t, slices.Range(0, 5, -1)).IsEmpty()
+	require.That(t, slices.Range[uint8](250, 255, 2)).Eq([]uint8{250, 252, 254})
+	require.That(t, slices.Range(0.0, 1.0, 0.25)).Eq([]float64{0, 0.25, 0.5, 0.75})
+	require.That(t, len(slices.Range(0.0, 1.0, 0.1))).Eq(10)
+	require.That(t, func() { slices.Range(0, 5, 0) }).Panics()
+}
+
+func TestRangeNarrowTypes(t *testing.T) {
+	require.That(t, slices.Range[int8](-100, 100, 50)).Eq([]int8{-100, -50, 0, 50})
+	require.That(t, slices.Range[int8](100, -100, -50)).Eq([]int8{100, 50, 0, -50})
+	require.That(t, slices.Range[int8](-128, 127, 1)).Length().Eq(255)
+	require.That(t, slices.Range[int8](120, 127, 5)).Eq([]int8{120, 125})
+	require.That(t, slices.Range[int8](-120, -128, -5)).Eq([]int8{-120, -125})
+	require.That(t, slices.Range[uint8](0, 255, 1)).Length().Eq(255)
+	require.That(t, slices.Range[uint8](0, 255, 100)).Eq([]uint8{0, 100, 200})
+	require.That(t, slices.Range[uint8](255, 0, 255)).IsEmpty()
+	require.That(t, slices.Range[uint8](250, 255, 10)).Eq([]uint8{250})
+}
+
+func TestRangeSeq(t *testing.T) {
+	var r []int
+	for v := range slices.RangeSeq(0, 100, 10) {
+		if v > 30 {
+			break
+		}
+		r = append(r, v)
+	}
+	require.That(t, r).Eq([]int{0, 10, 20, 30})
+}
+
+func TestRepeat(t *testing.T) {
+	require.That(t, slices.Repeat("a", 3)).Eq([]string{"a", "a", "a"})
+	require.That(t, slices.Repeat("a", 0)).IsEmpty()
+	require.That(t, func() { slices.Repeat("a", -1) }).
+		PanicsAndRecoveredValue().Eq("slices: Repeat n must not be negative, got -1")
+
+	var n = 0
+	for range slices.RepeatSeq("a", -1) {
+		if n++; n == 5 {
+			break
+		}
+	}
+	require.That(t, n).Eq(5)
+}
+
+func TestGenerate(t *testing.T) {
+	var r = slices.Generate(4, func(i int) string { return strconv.Itoa(i * i) })
+	require.That(t, r).Eq([]string{"0", "1", "4", "9"})
+	require.That(t, func() { slices.Generate(-2, strconv.Itoa) }).
+		PanicsAndRecoveredValue().Eq("slices: Generate n must not be negative, got -2")
+
+	var s []int
+	for v := range slices.GenerateSeq(3, func(i int) int { return -i }) {
+		s = append(s, v)
+	}
+	require.That(t, s).Eq([]int{0, -1, -2})
+}
+
+func TestUnfold(t *testing.T) {
+	var fib = func(s [2]int) (int, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 20
+	}
+	require.That(t, slices.Unfold([2]int{0, 1}, fib)).Eq(
+		[]int{0, 1, 1, 2, 3, 5, 8, 13})
+
+	var digits = func(n int) (int, int, bool) {
+		return n % 10, n / 10, n != 0
+	}
+	require.That(t, slices.Unfold(1234, digits)).Eq([]int{4, 3, 2, 1})
+}
+
+func TestIterate(t *testing.T) {
+	var double = func(v int) int { return v * 2 }
+	require.That(t, slices.Iterate(1, double, 5)).Eq([]int{1, 2, 4, 8, 16})
+	require.That(t, slices.Iterate(1, double, 0)).IsEmpty()
+	require.That(t, func() { slices.Iterate(1, double, -1) }).
+		PanicsAndRecoveredValue().Eq("slices: Iterate n must not be negative, got -1")
+
+	var r []int
+	for v := range slices.IterateSeq(1, double, -1) {
+		if v > 100 {
+			break
+		}
+		r = append(r, v)
+	}
+	require.That(t, r).Eq([]int{1, 2, 4, 8, 16, 32, 64})
+}
+
+func TestCycle(t *testing.T) {
+	require.That(t, slices.Cycle([]int{1, 2}, 3)).Eq([]int{1, 2, 1, 2, 1, 2})
+	require.That(t, slices.Cycle([]int{1, 2}, 0)).IsEmpty()
+	require.That(t, func() { slices.Cycle([]int{1, 2}, -1) }).
+		PanicsAndRecoveredValue().Eq("slices: Cycle n must not be negative, got -1")
+
+	var r []int
+	for v := range slices.CycleSeq([]int{1, 2, 3}, -1) {
+		if len(r) == 5 {
+			break
+		}
+		r = append(r, v)
+	}
+	require.That(t, r).Eq([]int{1, 2, 3, 1, 2})
+
+	for range slices.CycleSeq([]int{}, -1) {
+		t.Fatal("unexpected iteration")
+	}
+}
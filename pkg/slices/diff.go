@@ -0,0 +1,281 @@
+package slices
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EditOp is the type of operation of an Edit.
+type EditOp int
+
+const (
+	// Keep indicates an element present in both inputs.
+	Keep EditOp = iota
+
+	// Insert indicates an element only present in the second input.
+	Insert
+
+	// Delete indicates an element only present in the first input.
+	Delete
+)
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case Keep:
+		return "Keep"
+	case Insert:
+		return "Insert"
+	case Delete:
+		return "Delete"
+	}
+	return fmt.Sprintf("EditOp(%d)", int(op))
+}
+
+// Edit is a single step of an edit script. `Value` is the element of the
+// second input for Keep and Insert operations, and the element of the first
+// input for Delete operations.
+type Edit[T any] struct {
+	Op    EditOp
+	Value T
+}
+
+// Diff returns a minimal edit script that transforms `a` into `b`, using
+// Myers' O(ND) algorithm, where N is the combined length of the inputs and D
+// the number of inserted and deleted elements. Memory use is in O(N).
+func Diff[T comparable](a, b []T) []Edit[T] {
+	return diff(a, b, func(i, j int) bool { return a[i] == b[j] })
+}
+
+// DiffBy is a variant of Diff() where elements are considered equal if the
+// results of invoking `key` on them are equal. `key` is invoked once per
+// element.
+func DiffBy[T any, K comparable](a, b []T, key func(v T) K) []Edit[T] {
+	var ka, kb = Map(a, key), Map(b, key)
+	return diff(a, b, func(i, j int) bool { return ka[i] == kb[j] })
+}
+
+// LongestCommonSubsequence returns the longest sequence of elements that
+// appear in both `a` and `b` in the same relative order.
+func LongestCommonSubsequence[T comparable](a, b []T) []T {
+	return FilterMap(Diff(a, b), func(e Edit[T]) (T, bool) {
+		return e.Value, e.Op == Keep
+	})
+}
+
+// ErrPatchMismatch is returned by Patch() when an edit script does not apply
+// to the given input.
+var ErrPatchMismatch = errors.New("slices: edit script does not match input")
+
+// Patch applies the edit script to `a` and returns the resulting slice. When
+// `script` is the result of `Diff(a, b)`, the result is equal to `b`. It fails
+// with ErrPatchMismatch if the script does not consume exactly all the
+// elements of `a`.
+func Patch[T any](a []T, script []Edit[T]) ([]T, error) {
+	var r = make([]T, 0, len(a))
+	var i = 0
+	for _, e := range script {
+		if e.Op != Insert {
+			if i >= len(a) {
+				return nil, fmt.Errorf("%w: script extends past end of input", ErrPatchMismatch)
+			}
+			i++
+		}
+		if e.Op != Delete {
+			r = append(r, e.Value)
+		}
+	}
+	if i != len(a) {
+		return nil, fmt.Errorf("%w: script consumes %v of %v elements", ErrPatchMismatch, i, len(a))
+	}
+	return r, nil
+}
+
+// diff implements the linear-space variant of Myers' algorithm: it finds the
+// middle snake of an optimal path by searching forward from the start and
+// backward from the end simultaneously, then recursively compares the two
+// halves on either side, so that memory use stays in O(N+M).
+func diff[T any](a, b []T, eq func(i, j int) bool) []Edit[T] {
+	var d = differ[T]{a: a, b: b, eq: eq}
+	d.compare(0, len(a), 0, len(b))
+	return d.r
+}
+
+type differ[T any] struct {
+	a, b []T
+	eq   func(i, j int) bool
+	r    []Edit[T]
+}
+
+// compare appends to the result the edit script transforming a[a0:a1] into
+// b[b0:b1].
+func (d *differ[T]) compare(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.eq(a0, b0) {
+		d.r = append(d.r, Edit[T]{Op: Keep, Value: d.b[b0]})
+		a0++
+		b0++
+	}
+	var suffix = 0
+	for a0 < a1-suffix && b0 < b1-suffix && d.eq(a1-suffix-1, b1-suffix-1) {
+		suffix++
+	}
+	a1, b1 = a1-suffix, b1-suffix
+
+	if x, y, ok := d.bisect(a0, a1, b0, b1); ok {
+		d.compare(a0, x, b0, y)
+		d.compare(x, a1, y, b1)
+	} else {
+		for _, v := range d.a[a0:a1] {
+			d.r = append(d.r, Edit[T]{Op: Delete, Value: v})
+		}
+		for _, v := range d.b[b0:b1] {
+			d.r = append(d.r, Edit[T]{Op: Insert, Value: v})
+		}
+	}
+	for _, v := range d.b[b1 : b1+suffix] {
+		d.r = append(d.r, Edit[T]{Op: Keep, Value: v})
+	}
+}
+
+// bisect finds the point where the forward and backward searches over
+// a[a0:a1] and b[b0:b1] meet, which splits the comparison into two smaller
+// ones. It returns false if the inputs are empty or have nothing in common.
+func (d *differ[T]) bisect(a0, a1, b0, b1 int) (x, y int, ok bool) {
+	var n, m = a1 - a0, b1 - b0
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+	var maxD = (n + m + 1) / 2
+	var offset = maxD + 1
+	var vf = make([]int, 2*offset+1) // furthest x on each diagonal, forward
+	var vb = make([]int, 2*offset+1) // furthest x on each diagonal, backward
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+	var delta = n - m
+	var front = delta%2 != 0
+
+	// Diagonals running off the edges are trimmed from subsequent searches.
+	var fStart, fEnd, bStart, bEnd = 0, 0, 0, 0
+	for e := 0; e < maxD; e++ {
+		for k := -e + fStart; k <= e-fEnd; k += 2 {
+			var x int
+			if k == -e || (k != e && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			var y = x - k
+			for x < n && y < m && d.eq(a0+x, b0+y) {
+				x++
+				y++
+			}
+			vf[offset+k] = x
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case front:
+				var kb = offset + delta - k
+				if kb >= 0 && kb < len(vb) && vb[kb] != -1 && x >= n-vb[kb] {
+					return a0 + x, b0 + y, true
+				}
+			}
+		}
+
+		for k := -e + bStart; k <= e-bEnd; k += 2 {
+			var x int
+			if k == -e || (k != e && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			var y = x - k
+			for x < n && y < m && d.eq(a1-x-1, b1-y-1) {
+				x++
+				y++
+			}
+			vb[offset+k] = x
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !front:
+				var kf = offset + delta - k
+				if kf >= 0 && kf < len(vf) && vf[kf] != -1 {
+					var xf = vf[kf]
+					var yf = xf - (kf - offset)
+					if xf >= n-x {
+						return a0 + xf, b0 + yf, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+var editPrefix = [...]string{Keep: " ", Insert: "+", Delete: "-"}
+
+// UnifiedDiff renders the differences between `a` and `b` in a format similar
+// to the unified diff format, with one line per element. Changes are grouped
+// into hunks that include up to `context` unchanged lines around them. The
+// result is empty if the inputs are equal. UnifiedDiff panics if `context` is
+// negative.
+func UnifiedDiff(a, b []string, context int) string {
+	mustNotBeNegative("UnifiedDiff", "context", context)
+	var script = Diff(a, b)
+	var sb strings.Builder
+
+	// Index of the first and last changes of each hunk in the script.
+	var start, end = -1, -1
+	var flush = func() {
+		var from = max(start-context, 0)
+		var to = min(end+context+1, len(script))
+		var ai, bi = 1, 1
+		for _, e := range script[:from] {
+			if e.Op != Insert {
+				ai++
+			}
+			if e.Op != Delete {
+				bi++
+			}
+		}
+		var an, bn = 0, 0
+		for _, e := range script[from:to] {
+			if e.Op != Insert {
+				an++
+			}
+			if e.Op != Delete {
+				bn++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%v,%v +%v,%v @@\n", ai, an, bi, bn)
+		for _, e := range script[from:to] {
+			sb.WriteString(editPrefix[e.Op])
+			sb.WriteString(e.Value)
+			sb.WriteString("\n")
+		}
+	}
+	for i, e := range script {
+		if e.Op == Keep {
+			continue
+		}
+		if start >= 0 && i-end > 2*context {
+			flush()
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+		end = i
+	}
+	if start >= 0 {
+		flush()
+	}
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package slices_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestDiff(t *testing.T) {
+	var a = strings.Split("ABCABBA", "")
+	var b = strings.Split("CBABAC", "")
+	var script = slices.Diff(a, b)
+
+	var edits = slices.Count(script, func(e slices.Edit[string]) bool {
+		return e.Op != slices.Keep
+	})
+	require.That(t, edits).Eq(5)
+
+	var r, err = slices.Patch(a, script)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(b)
+}
+
+func TestDiffEdgeCases(t *testing.T) {
+	require.That(t, slices.Diff([]int{}, []int{})).IsEmpty()
+	require.That(t, slices.Diff([]int{1, 2}, []int{1, 2})).Eq([]slices.Edit[int]{
+		{Op: slices.Keep, Value: 1},
+		{Op: slices.Keep, Value: 2},
+	})
+	require.That(t, slices.Diff([]int{}, []int{1})).Eq([]slices.Edit[int]{
+		{Op: slices.Insert, Value: 1},
+	})
+	require.That(t, slices.Diff([]int{1}, []int{})).Eq([]slices.Edit[int]{
+		{Op: slices.Delete, Value: 1},
+	})
+}
+
+func TestDiffBy(t *testing.T) {
+	type record struct {
+		ID   int
+		Name string
+	}
+	var a = []record{{1, "a"}, {2, "b"}, {3, "c"}}
+	var b = []record{{1, "A"}, {3, "C"}, {4, "D"}}
+	var script = slices.DiffBy(a, b, func(r record) int { return r.ID })
+	require.That(t, slices.Map(script, func(e slices.Edit[record]) slices.EditOp {
+		return e.Op
+	})).Eq([]slices.EditOp{slices.Keep, slices.Delete, slices.Keep, slices.Insert})
+
+	var r, err = slices.Patch(a, script)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(b)
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	var a = []int{1, 2, 3, 4, 1}
+	var b = []int{3, 4, 1, 2, 1, 3}
+	require.That(t, slices.LongestCommonSubsequence(a, b)).Length().Eq(3)
+	require.That(t, slices.LongestCommonSubsequence(a, []int{})).IsEmpty()
+}
+
+func TestPatchMismatch(t *testing.T) {
+	var script = slices.Diff([]int{1, 2}, []int{2, 3})
+	var _, err = slices.Patch([]int{1}, script)
+	require.That(t, err).IsError(slices.ErrPatchMismatch)
+	_, err = slices.Patch([]int{1, 2, 3}, script)
+	require.That(t, err).IsError(slices.ErrPatchMismatch)
+}
+
+// lcsLength computes the length of the longest common subsequence with the
+// classic dynamic programming algorithm, as a reference for Diff().
+func lcsLength(a, b []int) int {
+	var dp = make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
+
+func TestDiffProperties(t *testing.T) {
+	var rnd = rand.New(rand.NewSource(42))
+	var randomSlice = func() []int {
+		return slices.Generate(rnd.Intn(20), func(int) int { return rnd.Intn(5) })
+	}
+	for i := 0; i < 500; i++ {
+		var a, b = randomSlice(), randomSlice()
+		var script = slices.Diff(a, b)
+
+		var r, err = slices.Patch(a, script)
+		require.That(t, err).IsNil()
+		require.That(t, r).Eq(b)
+
+		var keeps = slices.Count(script, func(e slices.Edit[int]) bool {
+			return e.Op == slices.Keep
+		})
+		require.That(t, keeps).Eq(lcsLength(a, b))
+	}
+}
+
+func TestDiffLargeInputs(t *testing.T) {
+	var a = slices.Range(0, 5000, 1)
+	var b = slices.Range(5000, 10000, 1)
+	var script = slices.Diff(a, b)
+	require.That(t, script).Length().Eq(10000)
+
+	var r, err = slices.Patch(a, script)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(b)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	var a = strings.Split("a b c d e f g h i j", " ")
+	var b = strings.Split("a b X d e f g h j k", " ")
+	require.That(t, slices.UnifiedDiff(a, b, 1)).Eq(strings.Join([]string{
+		"@@ -2,3 +2,3 @@",
+		" b",
+		"-c",
+		"+X",
+		" d",
+		"@@ -8,3 +8,3 @@",
+		" h",
+		"-i",
+		" j",
+		"+k",
+		"",
+	}, "\n"))
+	require.That(t, slices.UnifiedDiff(a, a, 3)).Eq("")
+	require.That(t, func() { slices.UnifiedDiff(a, b, -1) }).
+		PanicsAndRecoveredValue().Eq("slices: UnifiedDiff context must not be negative, got -1")
+}
@@ -0,0 +1,152 @@
+package slices
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Flatten returns a slice containing the elements of all the slices in `v`, in
+// order.
+func Flatten[T any](v [][]T) []T {
+	return Concat(v...)
+}
+
+// Nested is a constraint that permits slices of `T` nested up to 4 levels
+// deep, as accepted by FlattenDeep().
+type Nested[T any] interface {
+	~[]T | ~[][]T | ~[][][]T | ~[][][][]T
+}
+
+// FlattenDeep returns a slice containing all the `T` elements found in `v`,
+// regardless of the nesting depth, in order. Because the element type cannot
+// be inferred from the constraint, it must be specified explicitly, e.g.
+// `FlattenDeep[int](v)`.
+func FlattenDeep[T any, S Nested[T]](v S) []T {
+	var r []T
+	flattenDeep(reflect.ValueOf(v), reflect.TypeFor[T](), &r)
+	return r
+}
+
+func flattenDeep[T any](v reflect.Value, t reflect.Type, r *[]T) {
+	var leaf = v.Type().Elem() == t
+	for i := 0; i < v.Len(); i++ {
+		if leaf {
+			// Nil interface elements have no dynamic type and are
+			// collected as the zero value of `T`.
+			var x, _ = v.Index(i).Interface().(T)
+			*r = append(*r, x)
+		} else {
+			flattenDeep(v.Index(i), t, r)
+		}
+	}
+}
+
+// Concat returns a new slice containing the elements of all the input slices,
+// in order. The result is allocated once with the exact required capacity.
+func Concat[T any](v ...[]T) []T {
+	var n = 0
+	for _, vv := range v {
+		n += len(vv)
+	}
+	var r = make([]T, 0, n)
+	for _, vv := range v {
+		r = append(r, vv...)
+	}
+	return r
+}
+
+// Interleave returns a new slice containing the elements of the input slices
+// taken in a round-robin fashion: the first element of each input, then the
+// second element of each input, and so on. Inputs that are exhausted are
+// skipped, so that all the elements of all inputs are included in the result.
+func Interleave[T any](v ...[]T) []T {
+	var n, l = 0, 0
+	for _, vv := range v {
+		n += len(vv)
+		l = max(l, len(vv))
+	}
+	var r = make([]T, 0, n)
+	for i := 0; i < l; i++ {
+		for _, vv := range v {
+			if i < len(vv) {
+				r = append(r, vv[i])
+			}
+		}
+	}
+	return r
+}
+
+// Intersperse returns a copy of `v` with `sep` inserted between each pair of
+// consecutive elements.
+func Intersperse[T any](v []T, sep T) []T {
+	if len(v) == 0 {
+		return []T{}
+	}
+	var r = make([]T, 0, 2*len(v)-1)
+	for i, a := range v {
+		if i != 0 {
+			r = append(r, sep)
+		}
+		r = append(r, a)
+	}
+	return r
+}
+
+// RaggedPolicy defines how Transpose() handles input rows of different
+// lengths.
+type RaggedPolicy int
+
+const (
+	// RaggedTruncate truncates all rows to the length of the shortest one,
+	// like Zip().
+	RaggedTruncate RaggedPolicy = iota
+
+	// RaggedPad pads all rows to the length of the longest one with zero
+	// values.
+	RaggedPad
+
+	// RaggedError fails with ErrRagged if rows have different lengths.
+	RaggedError
+)
+
+// ErrRagged is returned by Transpose() with the RaggedError policy when the
+// input rows have different lengths.
+var ErrRagged = errors.New("slices: ragged input")
+
+// Transpose returns the transposition of the matrix `v`, where the i-th row of
+// the result contains the i-th element of each row of `v`. Rows of different
+// lengths are handled according to `policy`; an error is returned only with
+// the RaggedError policy.
+func Transpose[T any](v [][]T, policy RaggedPolicy) ([][]T, error) {
+	var minl, maxl = 0, 0
+	for i, vv := range v {
+		if i == 0 || len(vv) < minl {
+			minl = len(vv)
+		}
+		maxl = max(maxl, len(vv))
+	}
+	if policy == RaggedError && minl != maxl {
+		for i, vv := range v {
+			if len(vv) != len(v[0]) {
+				return nil, fmt.Errorf("%w: row %v has length %v, expected %v",
+					ErrRagged, i, len(vv), len(v[0]))
+			}
+		}
+	}
+
+	var l = minl
+	if policy == RaggedPad {
+		l = maxl
+	}
+	var r = make([][]T, l)
+	for j := range r {
+		r[j] = make([]T, len(v))
+		for i, vv := range v {
+			if j < len(vv) {
+				r[j][i] = vv[j]
+			}
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,71 @@
+package slices_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestFlatten(t *testing.T) {
+	var v = [][]int{{1, 2}, {}, {3}, nil, {4, 5}}
+	require.That(t, slices.Flatten(v)).Eq([]int{1, 2, 3, 4, 5})
+	require.That(t, slices.Flatten([][]int{})).IsEmpty()
+}
+
+func TestFlattenDeep(t *testing.T) {
+	var v = [][][]int{{{1, 2}, {3}}, {}, {{4}, {}, {5, 6}}}
+	require.That(t, slices.FlattenDeep[int](v)).Eq([]int{1, 2, 3, 4, 5, 6})
+	require.That(t, slices.FlattenDeep[int]([]int{1, 2})).Eq([]int{1, 2})
+
+	type Matrix [][]string
+	var m = Matrix{{"a"}, {"b", "c"}}
+	require.That(t, slices.FlattenDeep[string](m)).Eq([]string{"a", "b", "c"})
+
+	require.That(t, slices.FlattenDeep[any]([]any{1, nil})).Eq([]any{1, nil})
+	require.That(t, slices.FlattenDeep[any]([][]any{{nil}, {"a"}})).Eq([]any{nil, "a"})
+}
+
+func TestConcat(t *testing.T) {
+	var r = slices.Concat([]int{1, 2}, nil, []int{3})
+	require.That(t, r).Eq([]int{1, 2, 3})
+	require.That(t, r).Capacity().Eq(3)
+	require.That(t, slices.Concat[int]()).IsEmpty()
+}
+
+func TestInterleave(t *testing.T) {
+	var r = slices.Interleave([]int{1, 2, 3, 4}, []int{10}, []int{20, 21})
+	require.That(t, r).Eq([]int{1, 10, 20, 2, 21, 3, 4})
+	require.That(t, slices.Interleave[int]()).IsEmpty()
+}
+
+func TestIntersperse(t *testing.T) {
+	require.That(t, slices.Intersperse([]string{"a", "b", "c"}, ",")).Eq(
+		[]string{"a", ",", "b", ",", "c"})
+	require.That(t, slices.Intersperse([]string{"a"}, ",")).Eq([]string{"a"})
+	require.That(t, slices.Intersperse([]string{}, ",")).IsEmpty()
+}
+
+func TestTranspose(t *testing.T) {
+	var v = [][]int{{1, 2, 3}, {4, 5, 6}}
+	var r, err = slices.Transpose(v, slices.RaggedError)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq([][]int{{1, 4}, {2, 5}, {3, 6}})
+}
+
+func TestTransposeRagged(t *testing.T) {
+	var v = [][]int{{1, 2, 3}, {4}, {5, 6}}
+
+	var r, err = slices.Transpose(v, slices.RaggedTruncate)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq([][]int{{1, 4, 5}})
+	require.That(t, r).Eq(slices.Zip(v...))
+
+	r, err = slices.Transpose(v, slices.RaggedPad)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq([][]int{{1, 4, 5}, {2, 0, 6}, {3, 0, 0}})
+
+	r, err = slices.Transpose(v, slices.RaggedError)
+	require.That(t, err).IsError(slices.ErrRagged)
+	require.That(t, r).IsNil()
+}
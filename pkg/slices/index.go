@@ -0,0 +1,79 @@
+package slices
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DuplicatePolicy defines how KeyBy() handles elements that share the same
+// key.
+type DuplicatePolicy int
+
+const (
+	// FirstWins keeps the first element for each key.
+	FirstWins DuplicatePolicy = iota
+
+	// LastWins keeps the last element for each key.
+	LastWins
+
+	// DuplicateError fails with ErrDuplicateKey if two elements share the
+	// same key.
+	DuplicateError
+)
+
+// ErrDuplicateKey is returned by KeyBy() with the DuplicateError policy when
+// two elements share the same key.
+var ErrDuplicateKey = errors.New("slices: duplicate key")
+
+// KeyBy returns a map of the elements of `v` indexed by the value returned by
+// `key`. Elements sharing the same key are handled according to `policy`; an
+// error is returned only with the DuplicateError policy. Use GroupBy() to keep
+// all the elements for each key.
+func KeyBy[T any, K comparable](v []T, key func(a T) K, policy DuplicatePolicy) (map[K]T, error) {
+	var r = make(map[K]T, len(v))
+	for i, a := range v {
+		var k = key(a)
+		if _, ok := r[k]; ok {
+			switch policy {
+			case FirstWins:
+				continue
+			case DuplicateError:
+				return nil, fmt.Errorf("%w: '%v' at index %v", ErrDuplicateKey, k, i)
+			}
+		}
+		r[k] = a
+	}
+	return r, nil
+}
+
+// IndexOfBy returns a map of the positions in `v` of the elements for each
+// value returned by `key`, in increasing order.
+func IndexOfBy[T any, K comparable](v []T, key func(a T) K) map[K][]int {
+	var r = make(map[K][]int)
+	for i, a := range v {
+		var k = key(a)
+		r[k] = append(r[k], i)
+	}
+	return r
+}
+
+// Associate invokes `f` with each element of `v` and collects the returned
+// key-value pairs into a map. If multiple elements produce the same key, the
+// last one wins.
+func Associate[T any, K comparable, V any](v []T, f func(a T) (K, V)) map[K]V {
+	var r = make(map[K]V, len(v))
+	for _, a := range v {
+		var k, vv = f(a)
+		r[k] = vv
+	}
+	return r
+}
+
+// ToMap converts a slice of pairs into a map, using the first value of each
+// pair as key and the second as value. If multiple pairs have the same key,
+// the last one wins.
+func ToMap[K comparable, V any](v []Pair[K, V]) map[K]V {
+	return Associate(v, func(p Pair[K, V]) (K, V) {
+		return p.First, p.Second
+	})
+}
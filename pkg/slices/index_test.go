@@ -0,0 +1,50 @@
+package slices_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestKeyBy(t *testing.T) {
+	var v = []string{"apple", "avocado", "banana"}
+	var first = func(s string) byte { return s[0] }
+
+	var r, err = slices.KeyBy(v, first, slices.FirstWins)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(map[byte]string{'a': "apple", 'b': "banana"})
+
+	r, err = slices.KeyBy(v, first, slices.LastWins)
+	require.That(t, err).IsNil()
+	require.That(t, r).Eq(map[byte]string{'a': "avocado", 'b': "banana"})
+
+	r, err = slices.KeyBy(v, first, slices.DuplicateError)
+	require.That(t, err).IsError(slices.ErrDuplicateKey)
+	require.That(t, r).IsNil()
+
+	var r2, err2 = slices.KeyBy(v, strings.ToUpper, slices.DuplicateError)
+	require.That(t, err2).IsNil()
+	require.That(t, r2).Length().Eq(3)
+}
+
+func TestIndexOfBy(t *testing.T) {
+	var v = []int{3, 1, 4, 1, 5}
+	var r = slices.IndexOfBy(v, func(a int) bool { return a%2 == 0 })
+	require.That(t, r).Eq(map[bool][]int{false: {0, 1, 3, 4}, true: {2}})
+}
+
+func TestAssociate(t *testing.T) {
+	var v = []string{"a", "bb", "cc"}
+	var r = slices.Associate(v, func(s string) (int, string) {
+		return len(s), strings.ToUpper(s)
+	})
+	require.That(t, r).Eq(map[int]string{1: "A", 2: "CC"})
+}
+
+func TestToMap(t *testing.T) {
+	var v = []slices.Pair[string, int]{{"a", 1}, {"b", 2}, {"a", 3}}
+	require.That(t, slices.ToMap(v)).Eq(map[string]int{"a": 3, "b": 2})
+	require.That(t, slices.ToMap[string, int](nil)).IsEmpty()
+}
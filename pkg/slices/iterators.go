@@ -2,9 +2,13 @@ package slices
 
 // Cons returns a slice of slices consisting of successive overlapping n-tuple
 // of elements. All resulting slices have a length of `n`. The result is empty
-// if the input is shorter than `n`.
+// if the input is shorter than `n`. The resulting slices share the storage of
+// the input, but their capacity is clipped so that appending to one never
+// overwrites another; use ConsCopy() for fully independent slices. Cons panics
+// if `n` is not strictly positive.
 func Cons[T any](v []T, n int) [][]T {
-	var r = make([][]T, 0, len(v)-n+1)
+	mustBePositive("Cons", "n", n)
+	var r = make([][]T, 0, max(len(v)-n+1, 0))
 	EachCons(v, n, func(v []T) {
 		r = append(r, v)
 	})
@@ -13,17 +17,32 @@ func Cons[T any](v []T, n int) [][]T {
 
 // EachCons invokes `f` with each element returned by Cons()
 func EachCons[T any](v []T, n int, f func(v []T)) {
+	mustBePositive("Cons", "n", n)
 	var l = len(v) - n + 1
 	for i := 0; i < l; i++ {
-		f(v[i : i+n])
+		f(v[i : i+n : i+n])
 	}
 }
 
+// ConsCopy is a variant of Cons() where each resulting slice is an independent
+// copy of the input elements.
+func ConsCopy[T any](v []T, n int) [][]T {
+	var r = Cons(v, n)
+	for i, a := range r {
+		r[i] = append([]T(nil), a...)
+	}
+	return r
+}
+
 // Slice returns a slice of slices consisting of successive non-overlapping
 // n-tuple of elements. All resulting slices have a length of `n`, except the
-// last one which may be shorter.
+// last one which may be shorter. The resulting slices share the storage of the
+// input, but their capacity is clipped so that appending to one never
+// overwrites another; use SliceCopy() for fully independent slices. Slice
+// panics if `n` is not strictly positive.
 func Slice[T any](v []T, n int) [][]T {
-	var r = make([][]T, 0, len(v)-n+1)
+	mustBePositive("Slice", "n", n)
+	var r = make([][]T, 0, (len(v)+n-1)/n)
 	EachSlice(v, n, func(v []T) {
 		r = append(r, v)
 	})
@@ -32,15 +51,85 @@ func Slice[T any](v []T, n int) [][]T {
 
 // EachSlice invokes `f` with each element returned by Slice()
 func EachSlice[T any](v []T, n int, f func(v []T)) {
+	mustBePositive("Slice", "n", n)
 	var l = len(v)
 	for i := 0; i < l; i += n {
-		f(v[i:imin(l, i+n)])
+		var e = imin(l, i+n)
+		f(v[i:e:e])
+	}
+}
+
+// SliceCopy is a variant of Slice() where the resulting slices share a single
+// copy of the input elements, independent from the input.
+func SliceCopy[T any](v []T, n int) [][]T {
+	return Slice(append([]T(nil), v...), n)
+}
+
+// Partial defines how Windows() handles the trailing partial window, when the
+// input does not end on a window boundary.
+type Partial int
+
+const (
+	// DropPartial discards the trailing partial window.
+	DropPartial Partial = iota
+
+	// KeepPartial includes the trailing partial window, shorter than the
+	// others.
+	KeepPartial
+
+	// PadPartial includes the trailing partial window, padded to full size
+	// with the provided pad value.
+	PadPartial
+)
+
+// Windows returns a slice of slices consisting of successive windows of `size`
+// elements, starting every `step` elements. Windows overlap if `step` is
+// smaller than `size`, and elements are skipped if `step` is larger. If the
+// last elements of the input are not covered by any full window, the next
+// window is partial and handled according to `partial`, and padded with `pad`
+// if needed. Windows panics if `size` or `step` is not strictly positive.
+// `Cons(v, n)` is equivalent to `Windows(v, n, 1, DropPartial, pad)` and
+// `Slice(v, n)` to `Windows(v, n, n, KeepPartial, pad)`, for any `pad`.
+func Windows[T any](v []T, size, step int, partial Partial, pad T) [][]T {
+	var r [][]T
+	EachWindows(v, size, step, partial, pad, func(v []T) {
+		r = append(r, v)
+	})
+	return r
+}
+
+// EachWindows invokes `f` with each element returned by Windows(). Full and
+// kept partial windows are sub-slices of the input; only padded windows are
+// newly allocated.
+func EachWindows[T any](v []T, size, step int, partial Partial, pad T, f func(v []T)) {
+	mustBePositive("Windows", "size", size)
+	mustBePositive("Windows", "step", step)
+	var l = len(v)
+	var i, covered = 0, 0
+	for ; i+size <= l; i += step {
+		f(v[i : i+size : i+size])
+		covered = i + size
+	}
+	if i >= l || covered >= l {
+		return
+	}
+	switch partial {
+	case KeepPartial:
+		f(v[i:l:l])
+	case PadPartial:
+		var w = make([]T, size)
+		var n = copy(w, v[i:])
+		for j := n; j < size; j++ {
+			w[j] = pad
+		}
+		f(w)
 	}
 }
 
 // SliceBetween invokes the slicer function with each consecutive element (`a`,
 // `b`) and splits the input between `a` and `b` if the slicer returns true. The
-// result is a slice of slices containing all the resulting splits.
+// result is a slice of slices containing all the resulting splits, and is
+// empty if the input is empty.
 func SliceBetween[T any](v []T, slicer func(a, b T) bool) [][]T {
 	var r [][]T
 	EachSliceBetween(v, slicer, func(v []T) {
@@ -52,14 +141,17 @@ func SliceBetween[T any](v []T, slicer func(a, b T) bool) [][]T {
 // EachSliceBetween invokes `f` with each element returned by SliceBetween()
 func EachSliceBetween[T any](v []T, slicer func(a, b T) bool, f func(v []T)) {
 	var l = len(v)
+	if l == 0 {
+		return
+	}
 	var s = 0
 	for e := 1; e < l; e++ {
 		if slicer(v[e-1], v[e]) {
-			f(v[s:e])
+			f(v[s:e:e])
 			s = e
 		}
 	}
-	f(v[s:l])
+	f(v[s:l:l])
 }
 
 // SliceBy splits the input into contiguous slices for which the function
@@ -80,14 +172,52 @@ func EachSliceBy[T any, U comparable](v []T, slicer func(a T) U, f func(v []T))
 		n = slicer(vv)
 		if p != n {
 			if s != e {
-				f(v[s:e])
+				f(v[s:e:e])
 			}
 			s = e
 			p = n
 		}
 	}
 	if s != len(v) {
-		f(v[s:])
+		f(v[s:len(v):len(v)])
+	}
+}
+
+// Group associates a key with a slice of elements sharing that key.
+type Group[K any, T any] struct {
+	Key    K
+	Values []T
+}
+
+// GroupConsecutive splits the input into contiguous slices for which the
+// function `key` returns the same value, and returns each slice along with
+// its key. Unlike GroupBy(), non-adjacent elements with the same key end up in
+// separate groups.
+func GroupConsecutive[T any, K comparable](v []T, key func(a T) K) []Group[K, T] {
+	var r []Group[K, T]
+	EachGroupConsecutive(v, key, func(k K, v []T) {
+		r = append(r, Group[K, T]{Key: k, Values: v})
+	})
+	return r
+}
+
+// EachGroupConsecutive invokes `f` with the key and values of each element
+// returned by GroupConsecutive()
+func EachGroupConsecutive[T any, K comparable](v []T, key func(a T) K, f func(k K, v []T)) {
+	var p K
+	var s = 0
+	for e, vv := range v {
+		var n = key(vv)
+		if e == 0 {
+			p = n
+		} else if p != n {
+			f(p, v[s:e:e])
+			s = e
+			p = n
+		}
+	}
+	if s != len(v) {
+		f(p, v[s:len(v):len(v)])
 	}
 }
 
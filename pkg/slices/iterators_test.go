@@ -45,3 +45,60 @@ func TestZip(t *testing.T) {
 	require.That(t, slices.Zip(a, b)).Eq([][]int{{1, 4}, {2, 5}, {3, 6}})
 	require.That(t, slices.Zip(a, b, nil)).Eq([][]int{})
 }
+
+func TestGroupConsecutive(t *testing.T) {
+	var key = func(v int) bool {
+		return v%2 == 0
+	}
+	var v = []int{0, 2, 1, 3, 5, 4}
+	require.That(t, slices.GroupConsecutive(v, key)).Eq([]slices.Group[bool, int]{
+		{Key: true, Values: []int{0, 2}},
+		{Key: false, Values: []int{1, 3, 5}},
+		{Key: true, Values: []int{4}},
+	})
+	require.That(t, slices.GroupConsecutive([]int{1}, key)).Eq(
+		[]slices.Group[bool, int]{{Key: false, Values: []int{1}}})
+	require.That(t, slices.GroupConsecutive([]int{}, key)).IsEmpty()
+}
+
+func TestWindows(t *testing.T) {
+	var v = makeRange(7)
+	require.That(t, slices.Windows(v, 3, 2, slices.DropPartial, -1)).Eq(
+		[][]int{{0, 1, 2}, {2, 3, 4}, {4, 5, 6}})
+	require.That(t, slices.Windows(v, 4, 2, slices.DropPartial, -1)).Eq(
+		[][]int{{0, 1, 2, 3}, {2, 3, 4, 5}})
+	require.That(t, slices.Windows(v, 4, 2, slices.KeepPartial, -1)).Eq(
+		[][]int{{0, 1, 2, 3}, {2, 3, 4, 5}, {4, 5, 6}})
+	require.That(t, slices.Windows(v, 4, 2, slices.PadPartial, -1)).Eq(
+		[][]int{{0, 1, 2, 3}, {2, 3, 4, 5}, {4, 5, 6, -1}})
+}
+
+func TestWindowsWithHops(t *testing.T) {
+	var v = makeRange(12)
+	require.That(t, slices.Windows(v, 2, 5, slices.KeepPartial, -1)).Eq(
+		[][]int{{0, 1}, {5, 6}, {10, 11}})
+	require.That(t, slices.Windows(v, 2, 4, slices.PadPartial, -1)).Eq(
+		[][]int{{0, 1}, {4, 5}, {8, 9}})
+	require.That(t, slices.Windows(makeRange(11), 2, 5, slices.PadPartial, -1)).Eq(
+		[][]int{{0, 1}, {5, 6}, {10, -1}})
+}
+
+func TestWindowsMatchesConsAndSlice(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		for size := 1; size < 5; size++ {
+			var v = makeRange(size - 1 + n)
+			require.That(t, slices.Windows(v, size, 1, slices.DropPartial, 0)).Eq(
+				slices.Cons(v, size))
+			require.That(t, slices.Windows(v, size, size, slices.KeepPartial, 0)).Eq(
+				slices.Slice(v, size))
+		}
+	}
+}
+
+func TestWindowsSharesInput(t *testing.T) {
+	var v = makeRange(5)
+	var w = slices.Windows(v, 3, 2, slices.KeepPartial, 0)
+	w[0][2] = -1
+	require.That(t, w[1][0]).Eq(-1)
+	require.That(t, v[2]).Eq(-1)
+}
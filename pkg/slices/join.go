@@ -0,0 +1,138 @@
+package slices
+
+import "golang.org/x/exp/constraints"
+
+// Pair holds two associated values, such as the matching elements of a join.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Optional holds a value that may be missing, such as the unmatched side of
+// an outer join. `Ok` is false if the value is missing.
+type Optional[T any] struct {
+	Value T
+	Ok    bool
+}
+
+// Join returns all the pairs of elements of `left` and `right` for which
+// `leftKey` and `rightKey` return the same key. The result is ordered by
+// element of `left`, then by element of `right`. Join is a hash join built on
+// GroupBy(), running in O(n + m + k) time for inputs of length n and m and
+// k resulting pairs.
+func Join[L, R any, K comparable](left []L, right []R, leftKey func(l L) K, rightKey func(r R) K) []Pair[L, R] {
+	var index = GroupBy(right, rightKey)
+	var r []Pair[L, R]
+	for _, l := range left {
+		for _, rr := range index[leftKey(l)] {
+			r = append(r, Pair[L, R]{l, rr})
+		}
+	}
+	return r
+}
+
+// LeftJoin is a variant of Join() that also includes the elements of `left`
+// without matching element in `right`, paired with a missing value.
+func LeftJoin[L, R any, K comparable](left []L, right []R, leftKey func(l L) K, rightKey func(r R) K) []Pair[L, Optional[R]] {
+	var index = GroupBy(right, rightKey)
+	var r []Pair[L, Optional[R]]
+	for _, l := range left {
+		var matches = index[leftKey(l)]
+		if len(matches) == 0 {
+			r = append(r, Pair[L, Optional[R]]{First: l})
+		}
+		for _, rr := range matches {
+			r = append(r, Pair[L, Optional[R]]{l, Optional[R]{rr, true}})
+		}
+	}
+	return r
+}
+
+// FullOuterJoin is a variant of Join() that also includes the elements of
+// either input without matching element in the other, paired with a missing
+// value. Unmatched elements of `right` come last, in their original order.
+func FullOuterJoin[L, R any, K comparable](left []L, right []R, leftKey func(l L) K, rightKey func(r R) K) []Pair[Optional[L], Optional[R]] {
+	var index = GroupBy(right, rightKey)
+	var matched = make(map[K]struct{})
+	var r []Pair[Optional[L], Optional[R]]
+	for _, l := range left {
+		var k = leftKey(l)
+		var matches = index[k]
+		if len(matches) == 0 {
+			r = append(r, Pair[Optional[L], Optional[R]]{First: Optional[L]{l, true}})
+		}
+		for _, rr := range matches {
+			r = append(r, Pair[Optional[L], Optional[R]]{Optional[L]{l, true}, Optional[R]{rr, true}})
+		}
+		matched[k] = struct{}{}
+	}
+	for _, rr := range right {
+		if _, ok := matched[rightKey(rr)]; !ok {
+			r = append(r, Pair[Optional[L], Optional[R]]{Second: Optional[R]{rr, true}})
+		}
+	}
+	return r
+}
+
+// SemiJoin returns the elements of `left` that have at least one matching
+// element in `right`, in their original order. Unlike Join(), each element of
+// `left` is included at most once, regardless of the number of matches.
+func SemiJoin[L, R any, K comparable](left []L, right []R, leftKey func(l L) K, rightKey func(r R) K) []L {
+	var keys = keySet(right, rightKey)
+	return Filter(left, func(l L) bool {
+		var _, ok = keys[leftKey(l)]
+		return ok
+	})
+}
+
+// AntiJoin returns the elements of `left` that have no matching element in
+// `right`, in their original order.
+func AntiJoin[L, R any, K comparable](left []L, right []R, leftKey func(l L) K, rightKey func(r R) K) []L {
+	var keys = keySet(right, rightKey)
+	return Filter(left, func(l L) bool {
+		var _, ok = keys[leftKey(l)]
+		return !ok
+	})
+}
+
+// MergeJoin is a variant of Join() for inputs that are already sorted by
+// ascending key. It runs in O(n + m + k) time without allocating an index,
+// and returns the same result as Join() for sorted inputs. As with Join(),
+// NaN keys never match anything.
+func MergeJoin[L, R any, K constraints.Ordered](left []L, right []R, leftKey func(l L) K, rightKey func(r R) K) []Pair[L, R] {
+	var r []Pair[L, R]
+	var j = 0
+	for i := 0; i < len(left); {
+		var s, k = i, leftKey(left[i])
+		for i++; i < len(left) && leftKey(left[i]) == k; i++ {
+		}
+		for j < len(right) && (rightKey(right[j]) < k || isNaN(rightKey(right[j]))) {
+			j++
+		}
+		var e = j
+		for e < len(right) && rightKey(right[e]) == k {
+			e++
+		}
+		for _, l := range left[s:i] {
+			for _, rr := range right[j:e] {
+				r = append(r, Pair[L, R]{l, rr})
+			}
+		}
+		j = e
+	}
+	return r
+}
+
+// isNaN returns true if `k` is a floating-point NaN, the only value not equal
+// to itself.
+func isNaN[K comparable](k K) bool {
+	return k != k
+}
+
+func keySet[T any, K comparable](v []T, key func(a T) K) map[K]struct{} {
+	var r = make(map[K]struct{}, len(v))
+	for _, a := range v {
+		r[key(a)] = struct{}{}
+	}
+	return r
+}
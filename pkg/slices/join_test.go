@@ -0,0 +1,86 @@
+package slices_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type order struct {
+	UserID int
+	Item   string
+}
+
+var users = []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+var orders = []order{{1, "book"}, {3, "pen"}, {1, "lamp"}, {4, "desk"}}
+
+func userID(u user) int   { return u.ID }
+func orderID(o order) int { return o.UserID }
+
+func TestJoin(t *testing.T) {
+	var r = slices.Join(users, orders, userID, orderID)
+	require.That(t, r).Eq([]slices.Pair[user, order]{
+		{users[0], orders[0]},
+		{users[0], orders[2]},
+		{users[2], orders[1]},
+	})
+}
+
+func TestLeftJoin(t *testing.T) {
+	var r = slices.LeftJoin(users, orders, userID, orderID)
+	require.That(t, r).Eq([]slices.Pair[user, slices.Optional[order]]{
+		{users[0], slices.Optional[order]{Value: orders[0], Ok: true}},
+		{users[0], slices.Optional[order]{Value: orders[2], Ok: true}},
+		{users[1], slices.Optional[order]{}},
+		{users[2], slices.Optional[order]{Value: orders[1], Ok: true}},
+	})
+}
+
+func TestFullOuterJoin(t *testing.T) {
+	var r = slices.FullOuterJoin(users, orders, userID, orderID)
+	require.That(t, len(r)).Eq(5)
+	require.That(t, r[2].First.Value).Eq(users[1])
+	require.That(t, r[2].Second.Ok).IsFalse()
+	require.That(t, r[4].First.Ok).IsFalse()
+	require.That(t, r[4].Second).Eq(slices.Optional[order]{Value: orders[3], Ok: true})
+}
+
+func TestSemiJoin(t *testing.T) {
+	require.That(t, slices.SemiJoin(users, orders, userID, orderID)).Eq(
+		[]user{users[0], users[2]})
+}
+
+func TestAntiJoin(t *testing.T) {
+	require.That(t, slices.AntiJoin(users, orders, userID, orderID)).Eq(
+		[]user{users[1]})
+	require.That(t, slices.AntiJoin(orders, users, orderID, userID)).Eq(
+		[]order{orders[3]})
+}
+
+func TestMergeJoin(t *testing.T) {
+	var sorted = slices.StableSortBy(orders, orderID)
+	var r = slices.MergeJoin(users, sorted, userID, orderID)
+	require.That(t, r).Eq(slices.Join(users, sorted, userID, orderID))
+
+	var left = []int{1, 1, 2, 4, 5, 5}
+	var right = []int{0, 1, 1, 3, 5}
+	var id = func(v int) int { return v }
+	require.That(t, slices.MergeJoin(left, right, id, id)).Eq(slices.Join(left, right, id, id))
+	require.That(t, slices.MergeJoin([]int{}, right, id, id)).IsEmpty()
+}
+
+func TestMergeJoinNaNKeys(t *testing.T) {
+	var nan = math.NaN()
+	var id = func(v float64) float64 { return v }
+	var r = slices.MergeJoin([]float64{nan, 1, 2}, []float64{nan, 1, 2}, id, id)
+	require.That(t, r).Eq([]slices.Pair[float64, float64]{{1, 1}, {2, 2}})
+	require.That(t, slices.MergeJoin([]float64{1, nan, nan}, []float64{1}, id, id)).
+		Eq([]slices.Pair[float64, float64]{{1, 1}})
+}
@@ -0,0 +1,292 @@
+// Code generated by genmatrix; DO NOT EDIT.
+
+package slices_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// everyOther returns the elements of v with an even index, matching the
+// results kept by the FilterMap callbacks below.
+func everyOther(v [][]int) [][]int {
+	var r [][]int
+	for i, a := range v {
+		if i%2 == 0 {
+			r = append(r, a)
+		}
+	}
+	return r
+}
+
+func TestMatrixCons(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        []int
+		expected [][]int
+	}{
+		{"empty", []int{}, [][]int{}},
+		{"shorter", []int{0, 1}, [][]int{}},
+		{"exact", []int{0, 1, 2}, [][]int{{0, 1, 2}}},
+		{"longer", []int{0, 1, 2, 3, 4}, [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachCons(c.v, 3, func(a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapCons(c.v, 3, func(a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapCons(c.v, 3, func(a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapCons(c.v, 3, func(a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+
+func TestMatrixSlice(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        []int
+		expected [][]int
+	}{
+		{"empty", []int{}, [][]int{}},
+		{"shorter", []int{0, 1}, [][]int{{0, 1}}},
+		{"exact", []int{0, 1, 2, 3, 4, 5}, [][]int{{0, 1, 2}, {3, 4, 5}}},
+		{"partial", []int{0, 1, 2, 3}, [][]int{{0, 1, 2}, {3}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachSlice(c.v, 3, func(a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapSlice(c.v, 3, func(a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapSlice(c.v, 3, func(a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapSlice(c.v, 3, func(a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+
+func TestMatrixWindows(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        []int
+		expected [][]int
+	}{
+		{"empty", []int{}, [][]int{}},
+		{"shorter", []int{0, 1}, [][]int{{0, 1}}},
+		{"exact", []int{0, 1, 2, 3, 4}, [][]int{{0, 1, 2}, {2, 3, 4}}},
+		{"partial", []int{0, 1, 2, 3, 4, 5}, [][]int{{0, 1, 2}, {2, 3, 4}, {4, 5}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachWindows(c.v, 3, 2, slices.KeepPartial, 0, func(a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapWindows(c.v, 3, 2, slices.KeepPartial, 0, func(a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapWindows(c.v, 3, 2, slices.KeepPartial, 0, func(a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapWindows(c.v, 3, 2, slices.KeepPartial, 0, func(a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+
+func TestMatrixSliceBetween(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        []int
+		expected [][]int
+	}{
+		{"empty", []int{}, [][]int{}},
+		{"single", []int{1}, [][]int{{1}}},
+		{"sorted", []int{1, 2, 3}, [][]int{{1, 2, 3}}},
+		{"split", []int{1, 2, 4, 3, 5, 0}, [][]int{{1, 2, 4}, {3, 5}, {0}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachSliceBetween(c.v, func(a, b int) bool { return b < a }, func(a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapSliceBetween(c.v, func(a, b int) bool { return b < a }, func(a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapSliceBetween(c.v, func(a, b int) bool { return b < a }, func(a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapSliceBetween(c.v, func(a, b int) bool { return b < a }, func(a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+
+func TestMatrixSliceBy(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        []int
+		expected [][]int
+	}{
+		{"empty", []int{}, [][]int{}},
+		{"single", []int{1}, [][]int{{1}}},
+		{"split", []int{2, 4, 6, 3, 5, 8}, [][]int{{2, 4, 6}, {3, 5}, {8}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachSliceBy(c.v, func(a int) bool { return a%2 == 0 }, func(a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapSliceBy(c.v, func(a int) bool { return a%2 == 0 }, func(a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapSliceBy(c.v, func(a int) bool { return a%2 == 0 }, func(a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapSliceBy(c.v, func(a int) bool { return a%2 == 0 }, func(a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+
+func TestMatrixGroupConsecutive(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        []int
+		expected [][]int
+	}{
+		{"empty", []int{}, [][]int{}},
+		{"single", []int{1}, [][]int{{1}}},
+		{"split", []int{2, 4, 6, 3, 5, 8}, [][]int{{2, 4, 6}, {3, 5}, {8}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachGroupConsecutive(c.v, func(a int) bool { return a%2 == 0 }, func(_ bool, a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapGroupConsecutive(c.v, func(a int) bool { return a%2 == 0 }, func(_ bool, a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapGroupConsecutive(c.v, func(a int) bool { return a%2 == 0 }, func(_ bool, a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapGroupConsecutive(c.v, func(a int) bool { return a%2 == 0 }, func(_ bool, a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
+
+func TestMatrixZip(t *testing.T) {
+	var cases = []struct {
+		name     string
+		v        [][]int
+		expected [][]int
+	}{
+		{"empty", [][]int{}, [][]int{}},
+		{"uneven", [][]int{{1, 2, 3, 4}, {5, 6, 7}}, [][]int{{1, 5}, {2, 6}, {3, 7}}},
+		{"with-empty", [][]int{{1, 2}, {}}, [][]int{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var each [][]int
+			slices.EachZip(c.v, func(a []int) {
+				each = append(each, a)
+			})
+			require.That(t, each).Eq(c.expected)
+
+			var mapped = slices.MapZip(c.v, func(a []int) []int {
+				return a
+			})
+			require.That(t, mapped).Eq(c.expected)
+
+			var flat = slices.FlatMapZip(c.v, func(a []int) []int {
+				return a
+			})
+			require.That(t, flat).Eq(slices.Flatten(c.expected))
+
+			var i = 0
+			var filtered = slices.FilterMapZip(c.v, func(a []int) ([]int, bool) {
+				i++
+				return a, i%2 == 1
+			})
+			require.That(t, filtered).Eq(everyOther(c.expected))
+		})
+	}
+}
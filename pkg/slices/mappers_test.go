@@ -142,6 +142,36 @@ func TestFilterMapSliceBy(t *testing.T) {
 	require.That(t, r).Eq([]int{2})
 }
 
+func TestMapGroupConsecutive(t *testing.T) {
+	var v = []int{2, 4, 6, 3, 5}
+	var key = func(a int) bool { return a%2 == 0 }
+	var r = slices.MapGroupConsecutive(v, key, func(k bool, a []int) int {
+		if k {
+			return len(a)
+		}
+		return -len(a)
+	})
+	require.That(t, r).Eq([]int{3, -2})
+}
+
+func TestFlatMapGroupConsecutive(t *testing.T) {
+	var v = []int{2, 4, 6, 3, 5}
+	var key = func(a int) int { return a % 2 }
+	var r = slices.FlatMapGroupConsecutive(v, key, func(k int, a []int) []int {
+		return append(append([]int{}, a...), -k)
+	})
+	require.That(t, r).Eq([]int{2, 4, 6, 0, 3, 5, -1})
+}
+
+func TestFilterMapGroupConsecutive(t *testing.T) {
+	var v = []int{2, 4, 6, 3, 5}
+	var key = func(a int) bool { return a%2 == 0 }
+	var r = slices.FilterMapGroupConsecutive(v, key, func(k bool, a []int) (int, bool) {
+		return len(a), k
+	})
+	require.That(t, r).Eq([]int{3})
+}
+
 func TestMapZip(t *testing.T) {
 	var a = []int{1, 2, 3, 4}
 	var b = []int{5, 6, 7}
@@ -0,0 +1,185 @@
+package slices
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+// The functions below accept a random source `src`, such as `rand.NewPCG()` or
+// a `*rand.Rand`, so that seeded runs are reproducible. If `src` is nil, the
+// top-level functions of `math/rand/v2` are used instead.
+
+// Shuffle returns a copy of `v` with its elements in random order, with all
+// permutations equally likely. The original slice is not modified.
+func Shuffle[T any](v []T, src rand.Source) []T {
+	var r = newRand(src)
+	var s = append([]T{}, v...)
+	for i := len(s) - 1; i > 0; i-- {
+		var j = randIntN(r, i+1)
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}
+
+// Sample returns `k` distinct elements of `v` chosen at random, without
+// replacement, in random order. If `k` is larger than the length of `v`, all
+// the elements are returned. Sample panics if `k` is negative.
+func Sample[T any](v []T, k int, src rand.Source) []T {
+	if k < 0 {
+		panic("slices: Sample k must not be negative")
+	}
+	var r = newRand(src)
+	k = min(k, len(v))
+	var s = append([]T{}, v...)
+	for i := 0; i < k; i++ {
+		var j = i + randIntN(r, len(s)-i)
+		s[i], s[j] = s[j], s[i]
+	}
+	return s[:k:k]
+}
+
+// SampleWithReplacement returns `k` elements of `v` chosen independently at
+// random, so that the same element can be chosen multiple times. The result
+// is empty if `v` is empty. SampleWithReplacement panics if `k` is negative.
+func SampleWithReplacement[T any](v []T, k int, src rand.Source) []T {
+	if k < 0 {
+		panic("slices: SampleWithReplacement k must not be negative")
+	}
+	var r = newRand(src)
+	if len(v) == 0 {
+		return []T{}
+	}
+	var s = make([]T, k)
+	for i := range s {
+		s[i] = v[randIntN(r, len(v))]
+	}
+	return s
+}
+
+// Choice returns a random element of `v`. The second return value is false if
+// `v` is empty.
+func Choice[T any](v []T, src rand.Source) (T, bool) {
+	var r = newRand(src)
+	if len(v) == 0 {
+		var zero T
+		return zero, false
+	}
+	return v[randIntN(r, len(v))], true
+}
+
+// ReservoirSample returns `k` elements chosen at random, without replacement,
+// from a sequence of unknown length, consuming the sequence once and using
+// O(k) memory. If the sequence has fewer than `k` elements, all of them are
+// returned. ReservoirSample panics if `k` is negative.
+func ReservoirSample[T any](seq iter.Seq[T], k int, src rand.Source) []T {
+	if k < 0 {
+		panic("slices: ReservoirSample k must not be negative")
+	}
+	var r = newRand(src)
+	var s = make([]T, 0, k)
+	var n = 0
+	for v := range seq {
+		n++
+		if len(s) < k {
+			s = append(s, v)
+		} else if j := randIntN(r, n); j < k {
+			s[j] = v
+		}
+	}
+	return s
+}
+
+// WeightedSample returns `k` elements of `v` chosen independently at random,
+// with replacement, where the probability of each element is proportional to
+// the matching element of `weights`. It uses Vose's alias method, with O(n)
+// setup and O(1) time per chosen element. WeightedSample panics if the lengths
+// of `v` and `weights` differ, if any weight is negative, if all weights are
+// zero or if `k` is negative.
+func WeightedSample[T any](v []T, weights []float64, k int, src rand.Source) []T {
+	if k < 0 {
+		panic("slices: WeightedSample k must not be negative")
+	}
+	if len(v) != len(weights) {
+		panic("slices: WeightedSample requires one weight per element")
+	}
+	var prob, alias = aliasTable(weights)
+	var r = newRand(src)
+	var s = make([]T, k)
+	for i := range s {
+		var j = randIntN(r, len(v))
+		if randFloat64(r) >= prob[j] {
+			j = alias[j]
+		}
+		s[i] = v[j]
+	}
+	return s
+}
+
+// aliasTable builds the probability and alias tables of Vose's alias method.
+func aliasTable(weights []float64) (prob []float64, alias []int) {
+	var n = len(weights)
+	var total = 0.0
+	for _, w := range weights {
+		if w < 0 {
+			panic("slices: WeightedSample weights must not be negative")
+		}
+		total += w
+	}
+	if total <= 0 {
+		panic("slices: WeightedSample requires at least one positive weight")
+	}
+
+	prob = make([]float64, n)
+	alias = make([]int, n)
+	var small, large []int
+	var scaled = make([]float64, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		var s, l = small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		prob[s] = scaled[s]
+		alias[s] = l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Remaining entries have a probability of 1, up to rounding errors.
+	for _, i := range append(small, large...) {
+		prob[i] = 1
+	}
+	return prob, alias
+}
+
+// newRand returns a generator drawing from `src`, or nil if `src` is nil.
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		return nil
+	}
+	if r, ok := src.(*rand.Rand); ok {
+		return r
+	}
+	return rand.New(src)
+}
+
+func randIntN(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.IntN(n)
+	}
+	return r.IntN(n)
+}
+
+func randFloat64(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
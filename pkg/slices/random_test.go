@@ -0,0 +1,155 @@
+package slices_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+// chiSquare returns the chi-square statistic of the observed counts against
+// the expected counts.
+func chiSquare(observed []int, expected []float64) float64 {
+	var x2 = 0.0
+	for i, o := range observed {
+		var d = float64(o) - expected[i]
+		x2 += d * d / expected[i]
+	}
+	return x2
+}
+
+// uniform returns n expected counts of total/n each.
+func uniform(n, total int) []float64 {
+	return slices.Repeat(float64(total)/float64(n), n)
+}
+
+// Critical values of the chi-square distribution at p = 0.001, for the number
+// of degrees of freedom used below. With a fixed seed, the tests are
+// deterministic; the threshold only guards against a biased implementation.
+const (
+	chiSquare4DF  = 18.47
+	chiSquare9DF  = 27.88
+	chiSquare23DF = 49.73
+)
+
+func TestShuffle(t *testing.T) {
+	var v = makeRange(5)
+	var s = slices.Shuffle(v, newRand())
+	require.That(t, s).IsEqualSet(v)
+	require.That(t, v).Eq(makeRange(5))
+	require.That(t, slices.Shuffle(v, newRand())).Eq(s)
+	require.That(t, slices.Shuffle([]int{}, nil)).IsEmpty()
+}
+
+func TestShuffleUniformity(t *testing.T) {
+	var r = newRand()
+	var counts = make(map[[4]int]int)
+	var n = 24000
+	for i := 0; i < n; i++ {
+		counts[[4]int(slices.Shuffle(makeRange(4), r))]++
+	}
+	require.That(t, counts).Length().Eq(24)
+	var observed = make([]int, 0, 24)
+	for _, c := range counts {
+		observed = append(observed, c)
+	}
+	require.That(t, chiSquare(observed, uniform(24, n))).Lt(chiSquare23DF)
+}
+
+func TestSample(t *testing.T) {
+	var v = makeRange(10)
+	var s = slices.Sample(v, 4, newRand())
+	require.That(t, s).Length().Eq(4)
+	require.That(t, s).IsSubsetOf(v)
+	require.That(t, slices.Uniq(s)).Length().Eq(4)
+	require.That(t, slices.Sample(v, 20, newRand())).IsEqualSet(v)
+	require.That(t, func() { slices.Sample(v, -1, nil) }).Panics()
+}
+
+func TestSampleUniformity(t *testing.T) {
+	var r = newRand()
+	var counts = make([]int, 10)
+	var n = 10000
+	for i := 0; i < n; i++ {
+		for _, a := range slices.Sample(makeRange(10), 3, r) {
+			counts[a]++
+		}
+	}
+	require.That(t, chiSquare(counts, uniform(10, 3*n))).Lt(chiSquare9DF)
+}
+
+func TestSampleWithReplacement(t *testing.T) {
+	var r = newRand()
+	var s = slices.SampleWithReplacement(makeRange(5), 50000, r)
+	var counts = make([]int, 5)
+	for _, a := range s {
+		counts[a]++
+	}
+	require.That(t, chiSquare(counts, uniform(5, len(s)))).Lt(chiSquare4DF)
+	require.That(t, slices.SampleWithReplacement([]int{}, 3, r)).IsEmpty()
+}
+
+func TestChoice(t *testing.T) {
+	var v, ok = slices.Choice([]string{"a", "b"}, newRand())
+	require.That(t, ok).IsTrue()
+	require.That(t, []string{v}).IsSubsetOf([]string{"a", "b"})
+
+	_, ok = slices.Choice([]string{}, nil)
+	require.That(t, ok).IsFalse()
+}
+
+func TestReservoirSample(t *testing.T) {
+	var r = newRand()
+	require.That(t, slices.ReservoirSample(slices.RangeSeq(0, 3, 1), 5, r)).Eq([]int{0, 1, 2})
+
+	var counts = make([]int, 10)
+	var n = 10000
+	for i := 0; i < n; i++ {
+		for _, a := range slices.ReservoirSample(slices.RangeSeq(0, 10, 1), 3, r) {
+			counts[a]++
+		}
+	}
+	require.That(t, chiSquare(counts, uniform(10, 3*n))).Lt(chiSquare9DF)
+}
+
+func TestWeightedSample(t *testing.T) {
+	var r = newRand()
+	var v = []string{"a", "b", "c", "d", "e", "f"}
+	var weights = []float64{1, 2, 3, 0, 4, 10}
+	var n = 100000
+	var s = slices.WeightedSample(v, weights, n, r)
+	var counts = slices.CountBy(s, func(a string) string { return a })
+	require.That(t, counts).MapKeys().IsEqualSet([]string{"a", "b", "c", "e", "f"})
+
+	var observed []int
+	var expected []float64
+	for i, a := range v {
+		if weights[i] > 0 {
+			observed = append(observed, counts[a])
+			expected = append(expected, float64(n)*weights[i]/20)
+		}
+	}
+	require.That(t, chiSquare(observed, expected)).Lt(chiSquare4DF)
+}
+
+func TestWeightedSamplePanics(t *testing.T) {
+	require.That(t, func() { slices.WeightedSample([]int{1}, []float64{}, 1, nil) }).Panics()
+	require.That(t, func() { slices.WeightedSample([]int{1}, []float64{-1}, 1, nil) }).Panics()
+	require.That(t, func() { slices.WeightedSample([]int{1}, []float64{0}, 1, nil) }).Panics()
+	require.That(t, func() { slices.WeightedSample([]int{1}, []float64{1}, -1, nil) }).
+		PanicsAndRecoveredValue().Eq("slices: WeightedSample k must not be negative")
+}
+
+func TestRandomSource(t *testing.T) {
+	var v = makeRange(10)
+	var s = slices.Shuffle(v, rand.NewPCG(1, 2))
+	require.That(t, slices.Shuffle(v, rand.NewPCG(1, 2))).Eq(s)
+	require.That(t, slices.Shuffle(v, newRand())).Eq(s)
+	require.That(t, slices.WeightedSample(v, slices.Repeat(1.0, 10), 5, rand.NewPCG(1, 2))).
+		Eq(slices.WeightedSample(v, slices.Repeat(1.0, 10), 5, newRand()))
+}
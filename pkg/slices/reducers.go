@@ -1,5 +1,7 @@
 package slices
 
+import "golang.org/x/exp/constraints"
+
 // Reduce invokes `f` with each element of `v` and the updated memo from the
 // previous invocation.
 func Reduce[T, U any](v []T, memo U, f func(a T, memo U) U) U {
@@ -9,6 +11,74 @@ func Reduce[T, U any](v []T, memo U, f func(a T, memo U) U) U {
 	return memo
 }
 
+// ReduceRight invokes `f` with each element of `v`, starting from the last
+// one, and the updated memo from the previous invocation.
+func ReduceRight[T, U any](v []T, memo U, f func(a T, memo U) U) U {
+	for i := len(v) - 1; i >= 0; i-- {
+		memo = f(v[i], memo)
+	}
+	return memo
+}
+
+// Fold1 is a variant of Reduce() that uses the first element of `v` as initial
+// memo and invokes `f` with each of the remaining elements. The second return
+// value is false if `v` is empty.
+func Fold1[T any](v []T, f func(a T, memo T) T) (T, bool) {
+	if len(v) == 0 {
+		var zero T
+		return zero, false
+	}
+	return Reduce(v[1:], v[0], f), true
+}
+
+// Scan invokes `f` with each element of `v` and the updated memo from the
+// previous invocation, and collects every intermediate memo. The i-th element
+// of the result includes the contribution of the i-th element of `v`.
+func Scan[T, U any](v []T, memo U, f func(a T, memo U) U) []U {
+	var r = make([]U, 0, len(v))
+	for _, a := range v {
+		memo = f(a, memo)
+		r = append(r, memo)
+	}
+	return r
+}
+
+// ScanExclusive is a variant of Scan() where the i-th element of the result is
+// the memo before the contribution of the i-th element of `v`. The first
+// element of the result is the initial memo.
+func ScanExclusive[T, U any](v []T, memo U, f func(a T, memo U) U) []U {
+	var r = make([]U, 0, len(v))
+	for _, a := range v {
+		r = append(r, memo)
+		memo = f(a, memo)
+	}
+	return r
+}
+
+// ScanRight is a variant of Scan() that processes `v` starting from the last
+// element. The result is in the same order as the input, and its i-th element
+// includes the contribution of all the elements of `v` from index i onward.
+func ScanRight[T, U any](v []T, memo U, f func(a T, memo U) U) []U {
+	var r = make([]U, len(v))
+	for i := len(v) - 1; i >= 0; i-- {
+		memo = f(v[i], memo)
+		r[i] = memo
+	}
+	return r
+}
+
+// PrefixSum returns the inclusive running total of `v`. It is a faster
+// specialization of Scan() for numeric types.
+func PrefixSum[T Number](v []T) []T {
+	var r = make([]T, len(v))
+	var sum T
+	for i, a := range v {
+		sum += a
+		r[i] = sum
+	}
+	return r
+}
+
 // Count invokes `f` with each element of `v` and counts the true results.
 func Count[T any](s []T, f func(v T) bool) int {
 	var count = 0
@@ -41,3 +111,30 @@ func GroupBy[T any, U comparable](v []T, f func(v T) U) map[U][]T {
 	}
 	return r
 }
+
+// ReduceBy groups the elements of `v` by the value returned by `key` and
+// reduces each group independently, starting from the same initial `memo`.
+func ReduceBy[T any, K comparable, V any](v []T, key func(v T) K, memo V, f func(a T, memo V) V) map[K]V {
+	return AggregateBy(v, key, func(K) V { return memo }, f)
+}
+
+// AggregateBy groups the elements of `v` by the value returned by `key` and
+// reduces each group independently. The initial memo of each group is obtained
+// by invoking `seed` with the key of the group.
+func AggregateBy[T any, K comparable, V any](v []T, key func(v T) K, seed func(k K) V, f func(a T, memo V) V) map[K]V {
+	var r = make(map[K]V)
+	for _, a := range v {
+		var k = key(a)
+		var memo, ok = r[k]
+		if !ok {
+			memo = seed(k)
+		}
+		r[k] = f(a, memo)
+	}
+	return r
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
@@ -46,3 +46,65 @@ func TestGroupBy(t *testing.T) {
 	require.That(t, r).Field("even").Eq([]int{0, 2, 4})
 	require.That(t, r).Field("odd").Eq([]int{1, 3})
 }
+
+func TestReduceRight(t *testing.T) {
+	var v = []string{"a", "b", "c"}
+	var r = slices.ReduceRight(v, "", func(a string, memo string) string {
+		return memo + a
+	})
+	require.That(t, r).Eq("cba")
+}
+
+func TestFold1(t *testing.T) {
+	var sub = func(a int, memo int) int { return memo - a }
+	var r, ok = slices.Fold1([]int{10, 1, 2}, sub)
+	require.That(t, r).Eq(7)
+	require.That(t, ok).IsTrue()
+
+	r, ok = slices.Fold1([]int{}, sub)
+	require.That(t, r).Eq(0)
+	require.That(t, ok).IsFalse()
+}
+
+func TestScan(t *testing.T) {
+	var add = func(a int, memo int) int { return memo + a }
+	var v = []int{1, 2, 3, 4}
+	require.That(t, slices.Scan(v, 0, add)).Eq([]int{1, 3, 6, 10})
+	require.That(t, slices.ScanExclusive(v, 0, add)).Eq([]int{0, 1, 3, 6})
+	require.That(t, slices.ScanRight(v, 0, add)).Eq([]int{10, 9, 7, 4})
+	require.That(t, slices.Scan([]int{}, 0, add)).IsEmpty()
+}
+
+func TestPrefixSum(t *testing.T) {
+	require.That(t, slices.PrefixSum([]int{1, 2, 3, 4})).Eq([]int{1, 3, 6, 10})
+	require.That(t, slices.PrefixSum([]float64{0.5, 0.25})).Eq([]float64{0.5, 0.75})
+}
+
+func TestReduceBy(t *testing.T) {
+	var v = makeRange(5)
+	var r = slices.ReduceBy(v, func(a int) string {
+		if a%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}, 0, func(a int, memo int) int {
+		return memo + a
+	})
+	require.That(t, r).Field("even").Eq(6)
+	require.That(t, r).Field("odd").Eq(4)
+}
+
+func TestAggregateBy(t *testing.T) {
+	var v = []string{"apple", "avocado", "banana"}
+	var r = slices.AggregateBy(v, func(a string) byte {
+		return a[0]
+	}, func(k byte) string {
+		return string(k) + ":"
+	}, func(a string, memo string) string {
+		return memo + " " + a
+	})
+	require.That(t, r).Eq(map[byte]string{
+		'a': "a: apple avocado",
+		'b': "b: banana",
+	})
+}
@@ -0,0 +1,53 @@
+package slices
+
+// Run represents a sequence of `Count` consecutive elements equal to `Value`.
+type Run[T any] struct {
+	Value T
+	Count int
+}
+
+// RunLength returns the run-length encoding of `v`, as a slice of runs of
+// consecutive equal values.
+func RunLength[T comparable](v []T) []Run[T] {
+	return RunLengthBy(v, identity[T])
+}
+
+// RunLengthBy returns the run-length encoding of `v`, where consecutive
+// elements are considered equal if the results of invoking `f` on them are
+// equal. The value of each run is the first element of the run.
+func RunLengthBy[T any, U comparable](v []T, f func(a T) U) []Run[T] {
+	return MapSliceBy(v, f, func(a []T) Run[T] {
+		return Run[T]{Value: a[0], Count: len(a)}
+	})
+}
+
+// ExpandRuns is the inverse of RunLength() and returns a slice where the value
+// of each run is repeated `Count` times.
+func ExpandRuns[T any](runs []Run[T]) []T {
+	var n = 0
+	for _, r := range runs {
+		n += r.Count
+	}
+	var r = make([]T, 0, n)
+	for _, run := range runs {
+		for i := 0; i < run.Count; i++ {
+			r = append(r, run.Value)
+		}
+	}
+	return r
+}
+
+// Dedup returns a copy of `v` where consecutive duplicate elements are
+// collapsed into one. Unlike Uniq(), non-adjacent duplicates are preserved.
+func Dedup[T comparable](v []T) []T {
+	return DedupBy(v, identity[T])
+}
+
+// DedupBy returns a copy of `v` where consecutive elements for which `f`
+// returns the same value are collapsed into the first one. Unlike UniqBy(),
+// non-adjacent duplicates are preserved.
+func DedupBy[T any, U comparable](v []T, f func(a T) U) []T {
+	return MapSliceBy(v, f, func(a []T) T {
+		return a[0]
+	})
+}
@@ -0,0 +1,42 @@
+package slices_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func TestRunLength(t *testing.T) {
+	var v = []int{1, 1, 2, 3, 3, 3, 1}
+	var r = slices.RunLength(v)
+	require.That(t, r).Eq([]slices.Run[int]{
+		{Value: 1, Count: 2},
+		{Value: 2, Count: 1},
+		{Value: 3, Count: 3},
+		{Value: 1, Count: 1},
+	})
+	require.That(t, slices.ExpandRuns(r)).Eq(v)
+	require.That(t, slices.RunLength([]int{})).IsEmpty()
+}
+
+func TestRunLengthBy(t *testing.T) {
+	var v = []string{"a", "A", "b", "B", "b"}
+	var r = slices.RunLengthBy(v, strings.ToLower)
+	require.That(t, r).Eq([]slices.Run[string]{
+		{Value: "a", Count: 2},
+		{Value: "b", Count: 3},
+	})
+}
+
+func TestDedup(t *testing.T) {
+	var v = []int{1, 1, 2, 2, 1, 3, 3}
+	require.That(t, slices.Dedup(v)).Eq([]int{1, 2, 1, 3})
+	require.That(t, slices.Uniq(v)).Eq([]int{1, 2, 3})
+}
+
+func TestDedupBy(t *testing.T) {
+	var v = []string{"a", "A", "b", "a"}
+	require.That(t, slices.DedupBy(v, strings.ToLower)).Eq([]string{"a", "b", "a"})
+}
@@ -1,5 +1,7 @@
 package slices
 
+import "fmt"
+
 // Make is an helper function that creates a slice from individual elements
 // using type inference to determine the type of the resulting slice. All input
 // elements must have matching type.
@@ -9,9 +11,29 @@ func Make[T any](v ...T) []T {
 
 // Private helpers
 
+func identity[T any](v T) T {
+	return v
+}
+
 func imin(a, b int) int {
 	if a < b {
 		return a
 	}
 	return b
 }
+
+// mustBePositive panics with a descriptive message if the argument `arg` of the
+// traversal mode `mode` is not strictly positive.
+func mustBePositive(mode, arg string, n int) {
+	if n <= 0 {
+		panic(fmt.Sprintf("slices: %v %v must be positive, got %v", mode, arg, n))
+	}
+}
+
+// mustNotBeNegative panics with a descriptive message if the argument `arg` of
+// the function `fn` is negative.
+func mustNotBeNegative(fn, arg string, n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("slices: %v %v must not be negative, got %v", fn, arg, n))
+	}
+}
@@ -0,0 +1,74 @@
+package slices_test
+
+import (
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+// sizedWindowFunctions lists the window-based functions parameterized by a
+// window size, as a function of the input and size.
+var sizedWindowFunctions = map[string]func(v []int, n int) [][]int{
+	"Cons":      slices.Cons[int],
+	"ConsCopy":  slices.ConsCopy[int],
+	"Slice":     slices.Slice[int],
+	"SliceCopy": slices.SliceCopy[int],
+	"WindowsSize": func(v []int, n int) [][]int {
+		return slices.Windows(v, n, 1, slices.KeepPartial, 0)
+	},
+	"WindowsStep": func(v []int, n int) [][]int {
+		return slices.Windows(v, 1, n, slices.KeepPartial, 0)
+	},
+	"MapCons": func(v []int, n int) [][]int {
+		return slices.MapCons(v, n, func(a []int) []int { return a })
+	},
+	"FlatMapSlice": func(v []int, n int) [][]int {
+		return slices.FlatMapSlice(v, n, func(a []int) [][]int { return [][]int{a} })
+	},
+	"FilterMapWindows": func(v []int, n int) [][]int {
+		return slices.FilterMapWindows(v, n, n, slices.PadPartial, 0,
+			func(a []int) ([]int, bool) { return a, true })
+	},
+}
+
+func TestWindowFunctionsPanicOnInvalidSize(t *testing.T) {
+	for name, f := range sizedWindowFunctions {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{0, -1} {
+				require.That(t, func() { f(makeRange(3), n) }).
+					PanicsAndRecoveredValue().Matches(`^slices: \w+ \w+ must be positive, got -?\d+$`)
+			}
+		})
+	}
+}
+
+func TestWindowFunctionsWithSizeLargerThanInput(t *testing.T) {
+	for name, f := range sizedWindowFunctions {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{3, 4, 10} {
+				var r = f(makeRange(3), n)
+				require.That(t, len(r)).Le(1)
+			}
+		})
+	}
+	require.That(t, slices.Cons(makeRange(3), 5)).IsEmpty()
+	require.That(t, slices.Slice(makeRange(3), 5)).Eq([][]int{{0, 1, 2}})
+}
+
+func TestWindowFunctionsWithEmptyInput(t *testing.T) {
+	for name, f := range sizedWindowFunctions {
+		t.Run(name, func(t *testing.T) {
+			require.That(t, f([]int{}, 3)).IsEmpty()
+			require.That(t, f(nil, 1)).IsEmpty()
+		})
+	}
+
+	var isEven = func(a int) bool { return a%2 == 0 }
+	var lt = func(a, b int) bool { return b < a }
+	require.That(t, slices.SliceBetween([]int{}, lt)).IsEmpty()
+	require.That(t, slices.SliceBy([]int{}, isEven)).IsEmpty()
+	require.That(t, slices.GroupConsecutive([]int{}, isEven)).IsEmpty()
+	require.That(t, slices.Zip[int]()).IsEmpty()
+	require.That(t, slices.Zip([]int{}, []int{1})).IsEmpty()
+}
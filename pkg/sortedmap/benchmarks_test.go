@@ -0,0 +1,63 @@
+package sortedmap_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-generics/pkg/sortedmap"
+)
+
+func randomKeys(n int) []int {
+	var r = rand.New(rand.NewPCG(1, 2))
+	return slices.Generate(n, func(int) int { return r.IntN(n * 10) })
+}
+
+// BenchmarkSortedMapInsert1K inserts 1000 keys one at a time, keeping them
+// ordered after each insert.
+func BenchmarkSortedMapInsert1K(b *testing.B) {
+	var keys = randomKeys(1000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var m = sortedmap.NewOrdered[int, int]()
+		for _, k := range keys {
+			m.Set(k, k)
+		}
+	}
+}
+
+// BenchmarkSortAfterInsert1K is the baseline for BenchmarkSortedMapInsert1K,
+// appending each key to a slice and sorting it again.
+func BenchmarkSortAfterInsert1K(b *testing.B) {
+	var keys = randomKeys(1000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var v []int
+		for _, k := range keys {
+			v = slices.SortBy(append(v, k), func(k int) int { return k })
+		}
+	}
+}
+
+func BenchmarkSortedMapRange(b *testing.B) {
+	var m = sortedmap.NewOrdered[int, int]()
+	for _, k := range randomKeys(100000) {
+		m.Set(k, k)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for range m.Range(500000, 501000) {
+		}
+	}
+}
+
+func BenchmarkFromSorted100K(b *testing.B) {
+	var entries = slices.Map(slices.Range(0, 100000, 1), func(i int) sortedmap.Entry[int, int] {
+		return sortedmap.Entry[int, int]{Key: i, Value: i}
+	})
+	var less = func(a, b int) bool { return a < b }
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sortedmap.FromSorted(less, entries)
+	}
+}
@@ -0,0 +1,18 @@
+package sortedmap
+
+// MaxItems exposes the maximum number of entries per node to the tests.
+const MaxItems = maxItems
+
+// LargestNode returns the largest number of entries held by a single node of
+// `m`.
+func LargestNode[K any, V any](m *SortedMap[K, V]) int {
+	var r = 0
+	var stack = []*node[K, V]{m.root}
+	for len(stack) > 0 {
+		var n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		r = max(r, len(n.items))
+		stack = append(stack, n.children...)
+	}
+	return r
+}
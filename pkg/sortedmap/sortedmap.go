@@ -0,0 +1,557 @@
+// Package sortedmap provides SortedMap, an ordered map stored as a B-tree,
+// supporting ordered iteration, range queries and rank/select operations in
+// O(log n).
+package sortedmap
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// degree is the minimum degree of the B-tree: every node except the root
+// holds between degree-1 and 2*degree-1 entries.
+const degree = 16
+
+const maxItems = 2*degree - 1
+
+// ErrNotSorted is returned by FromSorted() when the input is not strictly
+// increasing.
+var ErrNotSorted = errors.New("sortedmap: input not strictly sorted")
+
+// Entry is a key-value pair of a sorted map.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// SortedMap is a map keeping its entries ordered by key, according to a `less`
+// function defining a strict weak ordering. Keys `a` and `b` are considered
+// equal if neither `less(a, b)` nor `less(b, a)`.
+type SortedMap[K any, V any] struct {
+	less func(a, b K) bool
+	root *node[K, V]
+}
+
+type node[K any, V any] struct {
+	items    []Entry[K, V]
+	children []*node[K, V]
+	size     int // number of entries in the subtree
+}
+
+// New creates a new empty sorted map ordered by `less`.
+func New[K any, V any](less func(a, b K) bool) *SortedMap[K, V] {
+	return &SortedMap[K, V]{less: less, root: &node[K, V]{}}
+}
+
+// NewOrdered creates a new empty sorted map ordered by the natural order of
+// the keys.
+func NewOrdered[K constraints.Ordered, V any]() *SortedMap[K, V] {
+	return New[K, V](func(a, b K) bool { return a < b })
+}
+
+// FromSorted creates a new sorted map ordered by `less` from entries already
+// sorted by strictly increasing key, building the tree in O(n) without
+// comparisons beyond checking the order. It returns an error wrapping
+// ErrNotSorted if the entries are not strictly increasing.
+func FromSorted[K any, V any](less func(a, b K) bool, entries []Entry[K, V]) (*SortedMap[K, V], error) {
+	for i := 1; i < len(entries); i++ {
+		if !less(entries[i-1].Key, entries[i].Key) {
+			return nil, fmt.Errorf("%w: at index %v", ErrNotSorted, i)
+		}
+	}
+	var m = New[K, V](less)
+	if len(entries) > 0 {
+		m.root = build(entries)
+	}
+	return m, nil
+}
+
+// build assembles a B-tree bottom up, starting with leaves holding all the
+// entries except the separators between them, then grouping nodes under
+// parents holding the separators, until a single root remains.
+func build[K any, V any](entries []Entry[K, V]) *node[K, V] {
+	var nodes []*node[K, V]
+	var seps []Entry[K, V]
+	var i = 0
+	for j, n := range chunks(len(entries)) {
+		if j > 0 {
+			seps = append(seps, entries[i])
+			i++
+		}
+		var items = append([]Entry[K, V](nil), entries[i:i+n]...)
+		nodes = append(nodes, &node[K, V]{items: items, size: n})
+		i += n
+	}
+
+	for len(nodes) > 1 {
+		var parents []*node[K, V]
+		var parentSeps []Entry[K, V]
+		var i, c = 0, 0
+		for j, n := range chunks(len(seps)) {
+			if j > 0 {
+				parentSeps = append(parentSeps, seps[i])
+				i++
+			}
+			var p = &node[K, V]{
+				items:    append([]Entry[K, V](nil), seps[i:i+n]...),
+				children: append([]*node[K, V](nil), nodes[c:c+n+1]...),
+				size:     n,
+			}
+			for _, child := range p.children {
+				p.size += child.size
+			}
+			parents = append(parents, p)
+			i += n
+			c += n + 1
+		}
+		nodes, seps = parents, parentSeps
+	}
+	return nodes[0]
+}
+
+// chunks splits `n` items into the smallest number of nodes of at most
+// maxItems, separated by one item each, and returns the number of items in
+// each node. The items are spread evenly, so that all nodes hold at least
+// degree-1 items when there is more than one.
+func chunks(n int) []int {
+	var k = (n + maxItems + 1) / (maxItems + 1)
+	var m = n - (k - 1)
+	var r = make([]int, k)
+	for i := range r {
+		r[i] = m / k
+		if i < m%k {
+			r[i]++
+		}
+	}
+	return r
+}
+
+// Len returns the number of entries in the map.
+func (m *SortedMap[K, V]) Len() int {
+	return m.root.size
+}
+
+// find returns the index of the first item of `n` not less than `k`, and
+// whether that item is equal to `k`.
+func (m *SortedMap[K, V]) find(n *node[K, V], k K) (int, bool) {
+	var lo, hi = 0, len(n.items)
+	for lo < hi {
+		var h = int(uint(lo+hi) >> 1)
+		if m.less(n.items[h].Key, k) {
+			lo = h + 1
+		} else {
+			hi = h
+		}
+	}
+	return lo, lo < len(n.items) && !m.less(k, n.items[lo].Key)
+}
+
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// Get returns the value associated with `k`. The second return value is false
+// if `k` is not in the map.
+func (m *SortedMap[K, V]) Get(k K) (v V, ok bool) {
+	for n := m.root; ; {
+		var i, found = m.find(n, k)
+		if found {
+			return n.items[i].Value, true
+		}
+		if n.leaf() {
+			return v, false
+		}
+		n = n.children[i]
+	}
+}
+
+// Contains returns true if `k` is in the map.
+func (m *SortedMap[K, V]) Contains(k K) bool {
+	var _, ok = m.Get(k)
+	return ok
+}
+
+// Set associates `v` with `k`, and returns true if `k` was not already in the
+// map.
+func (m *SortedMap[K, V]) Set(k K, v V) bool {
+	if len(m.root.items) >= maxItems {
+		var root = &node[K, V]{children: []*node[K, V]{m.root}, size: m.root.size}
+		root.split(0)
+		m.root = root
+	}
+	return m.insert(m.root, k, v)
+}
+
+func (m *SortedMap[K, V]) insert(n *node[K, V], k K, v V) bool {
+	var i, found = m.find(n, k)
+	if found {
+		n.items[i].Value = v
+		return false
+	}
+	if n.leaf() {
+		n.items = insertAt(n.items, i, Entry[K, V]{k, v})
+		n.size++
+		return true
+	}
+	if len(n.children[i].items) >= maxItems {
+		n.split(i)
+		switch {
+		case m.less(n.items[i].Key, k):
+			i++
+		case !m.less(k, n.items[i].Key):
+			n.items[i].Value = v
+			return false
+		}
+	}
+	var added = m.insert(n.children[i], k, v)
+	if added {
+		n.size++
+	}
+	return added
+}
+
+// split splits the full child `i` of `n` in two, moving its median entry into
+// `n`.
+func (n *node[K, V]) split(i int) {
+	var left = n.children[i]
+	var right = &node[K, V]{
+		items: append([]Entry[K, V](nil), left.items[degree:]...),
+	}
+	var median = left.items[degree-1]
+	clear(left.items[degree-1:])
+	left.items = left.items[:degree-1]
+	if !left.leaf() {
+		right.children = append([]*node[K, V](nil), left.children[degree:]...)
+		clear(left.children[degree:])
+		left.children = left.children[:degree]
+	}
+	right.size = right.count()
+	left.size -= right.size + 1
+
+	n.items = insertAt(n.items, i, median)
+	n.children = insertAt(n.children, i+1, right)
+}
+
+// count computes the size of the subtree of `n` from the sizes of its
+// children.
+func (n *node[K, V]) count() int {
+	var s = len(n.items)
+	for _, c := range n.children {
+		s += c.size
+	}
+	return s
+}
+
+// Delete removes `k` from the map and returns true if it was present.
+func (m *SortedMap[K, V]) Delete(k K) bool {
+	var removed = m.delete(m.root, k)
+	if len(m.root.items) == 0 && !m.root.leaf() {
+		m.root = m.root.children[0]
+	}
+	return removed
+}
+
+func (m *SortedMap[K, V]) delete(n *node[K, V], k K) bool {
+	var i, found = m.find(n, k)
+	if n.leaf() {
+		if !found {
+			return false
+		}
+		n.items = removeAt(n.items, i)
+		n.size--
+		return true
+	}
+
+	if found {
+		// Replace the entry with its predecessor or successor, then remove
+		// that one from the child it came from.
+		switch {
+		case len(n.children[i].items) >= degree:
+			var c = n.children[i]
+			var pred = c.last()
+			n.items[i] = pred
+			m.delete(c, pred.Key)
+		case len(n.children[i+1].items) >= degree:
+			var c = n.children[i+1]
+			var succ = c.first()
+			n.items[i] = succ
+			m.delete(c, succ.Key)
+		default:
+			n.merge(i)
+			m.delete(n.children[i], k)
+		}
+		n.size--
+		return true
+	}
+
+	if len(n.children[i].items) < degree {
+		i = n.grow(i)
+	}
+	var removed = m.delete(n.children[i], k)
+	if removed {
+		n.size--
+	}
+	return removed
+}
+
+// grow ensures that child `i` of `n` holds at least `degree` entries, by
+// borrowing an entry from a sibling or merging with it, and returns the new
+// index of the child.
+func (n *node[K, V]) grow(i int) int {
+	switch {
+	case i > 0 && len(n.children[i-1].items) >= degree:
+		var c, l = n.children[i], n.children[i-1]
+		c.items = insertAt(c.items, 0, n.items[i-1])
+		n.items[i-1] = l.items[len(l.items)-1]
+		l.items = removeAt(l.items, len(l.items)-1)
+		var moved = 1
+		if !l.leaf() {
+			var gc = l.children[len(l.children)-1]
+			l.children = removeAt(l.children, len(l.children)-1)
+			c.children = insertAt(c.children, 0, gc)
+			moved += gc.size
+		}
+		c.size += moved
+		l.size -= moved
+		return i
+	case i < len(n.items) && len(n.children[i+1].items) >= degree:
+		var c, r = n.children[i], n.children[i+1]
+		c.items = append(c.items, n.items[i])
+		n.items[i] = r.items[0]
+		r.items = removeAt(r.items, 0)
+		var moved = 1
+		if !r.leaf() {
+			var gc = r.children[0]
+			r.children = removeAt(r.children, 0)
+			c.children = append(c.children, gc)
+			moved += gc.size
+		}
+		c.size += moved
+		r.size -= moved
+		return i
+	case i < len(n.items):
+		n.merge(i)
+		return i
+	default:
+		n.merge(i - 1)
+		return i - 1
+	}
+}
+
+// merge merges child `i+1` of `n` and the entry separating them into child
+// `i`.
+func (n *node[K, V]) merge(i int) {
+	var l, r = n.children[i], n.children[i+1]
+	l.items = append(append(l.items, n.items[i]), r.items...)
+	l.children = append(l.children, r.children...)
+	l.size += r.size + 1
+	n.items = removeAt(n.items, i)
+	n.children = removeAt(n.children, i+1)
+}
+
+func (n *node[K, V]) first() Entry[K, V] {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.items[0]
+}
+
+func (n *node[K, V]) last() Entry[K, V] {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.items[len(n.items)-1]
+}
+
+// Min returns the entry with the smallest key. The last return value is false
+// if the map is empty.
+func (m *SortedMap[K, V]) Min() (k K, v V, ok bool) {
+	if m.Len() == 0 {
+		return k, v, false
+	}
+	var e = m.root.first()
+	return e.Key, e.Value, true
+}
+
+// Max returns the entry with the largest key. The last return value is false
+// if the map is empty.
+func (m *SortedMap[K, V]) Max() (k K, v V, ok bool) {
+	if m.Len() == 0 {
+		return k, v, false
+	}
+	var e = m.root.last()
+	return e.Key, e.Value, true
+}
+
+// Floor returns the entry with the largest key less than or equal to `k`. The
+// last return value is false if there is no such entry.
+func (m *SortedMap[K, V]) Floor(k K) (K, V, bool) {
+	var best *Entry[K, V]
+	for n := m.root; ; {
+		var i, found = m.find(n, k)
+		if found {
+			return n.items[i].Key, n.items[i].Value, true
+		}
+		if i > 0 {
+			best = &n.items[i-1]
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return result(best)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to
+// `k`. The last return value is false if there is no such entry.
+func (m *SortedMap[K, V]) Ceiling(k K) (K, V, bool) {
+	var best *Entry[K, V]
+	for n := m.root; ; {
+		var i, found = m.find(n, k)
+		if found {
+			return n.items[i].Key, n.items[i].Value, true
+		}
+		if i < len(n.items) {
+			best = &n.items[i]
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return result(best)
+}
+
+func result[K any, V any](e *Entry[K, V]) (k K, v V, ok bool) {
+	if e == nil {
+		return k, v, false
+	}
+	return e.Key, e.Value, true
+}
+
+// Rank returns the number of keys of the map strictly less than `k`, which is
+// the index of `k` in the ordered sequence of keys if present.
+func (m *SortedMap[K, V]) Rank(k K) int {
+	var r = 0
+	for n := m.root; ; {
+		var i, found = m.find(n, k)
+		r += i
+		if n.leaf() {
+			return r
+		}
+		for _, c := range n.children[:i] {
+			r += c.size
+		}
+		if found {
+			return r + n.children[i].size
+		}
+		n = n.children[i]
+	}
+}
+
+// Select returns the entry at index `i` in the ordered sequence of entries. The
+// last return value is false if `i` is out of range.
+func (m *SortedMap[K, V]) Select(i int) (k K, v V, ok bool) {
+	if i < 0 || i >= m.Len() {
+		return k, v, false
+	}
+	var n = m.root
+outer:
+	for {
+		for j := range n.items {
+			if !n.leaf() {
+				var c = n.children[j]
+				if i < c.size {
+					n = c
+					continue outer
+				}
+				i -= c.size
+			}
+			if i == 0 {
+				return n.items[j].Key, n.items[j].Value, true
+			}
+			i--
+		}
+		n = n.children[len(n.children)-1]
+	}
+}
+
+// All returns an iterator over the entries of the map, by increasing key. The
+// map must not be modified during iteration.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, nil, nil, yield)
+	}
+}
+
+// Range returns an iterator over the entries of the map with keys in the
+// half-open interval [`lo`, `hi`), by increasing key. The map must not be
+// modified during iteration.
+func (m *SortedMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, &lo, &hi, yield)
+	}
+}
+
+// ascend invokes `yield` in order with the entries of `n` between the optional
+// bounds `lo` and `hi`, and returns false if the iteration should stop.
+func (m *SortedMap[K, V]) ascend(n *node[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	var i = 0
+	if lo != nil {
+		i, _ = m.find(n, *lo)
+	}
+	for ; i <= len(n.items); i++ {
+		if !n.leaf() && !m.ascend(n.children[i], lo, hi, yield) {
+			return false
+		}
+		if i == len(n.items) {
+			break
+		}
+		var e = &n.items[i]
+		if hi != nil && !m.less(e.Key, *hi) {
+			return false
+		}
+		if !yield(e.Key, e.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns the keys of the map by increasing order.
+func (m *SortedMap[K, V]) Keys() []K {
+	var r = make([]K, 0, m.Len())
+	for k := range m.All() {
+		r = append(r, k)
+	}
+	return r
+}
+
+// Entries returns the entries of the map by increasing key, in a form
+// suitable for FromSorted().
+func (m *SortedMap[K, V]) Entries() []Entry[K, V] {
+	var r = make([]Entry[K, V], 0, m.Len())
+	for k, v := range m.All() {
+		r = append(r, Entry[K, V]{k, v})
+	}
+	return r
+}
+
+func insertAt[T any](v []T, i int, x T) []T {
+	var zero T
+	v = append(v, zero)
+	copy(v[i+1:], v[i:])
+	v[i] = x
+	return v
+}
+
+func removeAt[T any](v []T, i int) []T {
+	var zero T
+	copy(v[i:], v[i+1:])
+	v[len(v)-1] = zero
+	return v[:len(v)-1]
+}
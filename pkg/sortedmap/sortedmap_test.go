@@ -0,0 +1,198 @@
+package sortedmap_test
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-generics/pkg/sortedmap"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+func collect[K, V any](seq func(yield func(K, V) bool)) []K {
+	var r []K
+	for k := range seq {
+		r = append(r, k)
+	}
+	return r
+}
+
+func TestSortedMapEmpty(t *testing.T) {
+	var m = sortedmap.NewOrdered[int, string]()
+	require.That(t, m.Len()).Eq(0)
+	var _, ok = m.Get(1)
+	require.That(t, ok).IsFalse()
+	_, _, ok = m.Min()
+	require.That(t, ok).IsFalse()
+	_, _, ok = m.Max()
+	require.That(t, ok).IsFalse()
+	_, _, ok = m.Floor(1)
+	require.That(t, ok).IsFalse()
+	_, _, ok = m.Select(0)
+	require.That(t, ok).IsFalse()
+	require.That(t, m.Delete(1)).IsFalse()
+	require.That(t, m.Keys()).IsEmpty()
+}
+
+func TestSortedMapCustomOrder(t *testing.T) {
+	var m = sortedmap.New[string, int](func(a, b string) bool {
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	require.That(t, m.Set("b", 1)).IsTrue()
+	require.That(t, m.Set("A", 2)).IsTrue()
+	require.That(t, m.Set("a", 3)).IsFalse()
+	require.That(t, m.Keys()).Eq([]string{"A", "b"})
+	var v, _ = m.Get("A")
+	require.That(t, v).Eq(3)
+}
+
+func TestSortedMapFloorCeiling(t *testing.T) {
+	var m = sortedmap.NewOrdered[int, int]()
+	for i := 0; i < 1000; i += 10 {
+		m.Set(i, i)
+	}
+	var k, _, ok = m.Floor(55)
+	require.That(t, k).Eq(50)
+	require.That(t, ok).IsTrue()
+	k, _, _ = m.Floor(60)
+	require.That(t, k).Eq(60)
+	_, _, ok = m.Floor(-1)
+	require.That(t, ok).IsFalse()
+
+	k, _, _ = m.Ceiling(55)
+	require.That(t, k).Eq(60)
+	k, _, _ = m.Ceiling(-1)
+	require.That(t, k).Eq(0)
+	_, _, ok = m.Ceiling(991)
+	require.That(t, ok).IsFalse()
+
+	k, _, _ = m.Min()
+	require.That(t, k).Eq(0)
+	k, _, _ = m.Max()
+	require.That(t, k).Eq(990)
+}
+
+func TestSortedMapRange(t *testing.T) {
+	var m = sortedmap.NewOrdered[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+	require.That(t, collect(m.Range(100, 200))).Eq(slices.Range(100, 200, 1))
+	require.That(t, collect(m.Range(-10, 5))).Eq(slices.Range(0, 5, 1))
+	require.That(t, collect(m.Range(995, 2000))).Eq(slices.Range(995, 1000, 1))
+	require.That(t, collect(m.Range(5, 5))).IsEmpty()
+
+	var r []int
+	for k := range m.All() {
+		if k == 10 {
+			break
+		}
+		r = append(r, k)
+	}
+	require.That(t, r).Eq(slices.Range(0, 10, 1))
+}
+
+func TestFromSorted(t *testing.T) {
+	var less = func(a, b int) bool { return a < b }
+	for _, n := range []int{0, 1, 31, 32, 63, 64, 1000, 1024, 50000} {
+		var entries = slices.Map(slices.Range(0, n, 1), func(i int) sortedmap.Entry[int, int] {
+			return sortedmap.Entry[int, int]{Key: i * 2, Value: i}
+		})
+		var m, err = sortedmap.FromSorted(less, entries)
+		require.That(t, err).IsNil()
+		require.That(t, m.Len()).Eq(n)
+		require.That(t, m.Entries()).Eq(entries)
+		require.That(t, sortedmap.LargestNode(m)).Le(sortedmap.MaxItems)
+		if n > 0 {
+			var k, _, _ = m.Select(n / 2)
+			require.That(t, k).Eq(n / 2 * 2)
+			require.That(t, m.Rank(n/2*2+1)).Eq(n/2 + 1)
+		}
+
+		// The bulk-loaded tree must remain valid through updates
+		for i := 0; i < n; i += 2 {
+			require.That(t, m.Delete(i*2)).IsTrue()
+			m.Set(i*2+1, i)
+		}
+		require.That(t, m.Len()).Eq(n)
+		require.That(t, sortedmap.LargestNode(m)).Le(sortedmap.MaxItems)
+		var keys = m.Keys()
+		require.That(t, slices.Sort(keys, less)).Eq(keys)
+	}
+}
+
+func TestFromSortedThenSet(t *testing.T) {
+	var less = func(a, b int) bool { return a < b }
+	for _, n := range []int{31, 32, 64, 1024} {
+		var entries = slices.Map(slices.Range(0, n, 1), func(i int) sortedmap.Entry[int, int] {
+			return sortedmap.Entry[int, int]{Key: i, Value: i}
+		})
+		var m, _ = sortedmap.FromSorted(less, entries)
+		for i := 0; i < 2000; i++ {
+			m.Set(n+i, i)
+			require.That(t, sortedmap.LargestNode(m)).Le(sortedmap.MaxItems)
+		}
+		require.That(t, m.Len()).Eq(n + 2000)
+	}
+}
+
+func TestFromSortedError(t *testing.T) {
+	var less = func(a, b int) bool { return a < b }
+	var _, err = sortedmap.FromSorted(less, []sortedmap.Entry[int, int]{
+		{Key: 1}, {Key: 2}, {Key: 2},
+	})
+	require.That(t, err).IsError(sortedmap.ErrNotSorted)
+}
+
+// TestSortedMapMatchesSortedSlice applies random operations to a sorted map
+// and to a reference sorted slice, and checks that all queries agree.
+func TestSortedMapMatchesSortedSlice(t *testing.T) {
+	var r = rand.New(rand.NewPCG(1, 2))
+	var m = sortedmap.NewOrdered[int, int]()
+	var ref = map[int]int{}
+
+	for step := 0; step < 20000; step++ {
+		var k = r.IntN(3000)
+		if r.IntN(3) == 0 {
+			var _, present = ref[k]
+			require.That(t, m.Delete(k)).Eq(present)
+			delete(ref, k)
+		} else {
+			var _, present = ref[k]
+			require.That(t, m.Set(k, step)).Eq(!present)
+			ref[k] = step
+		}
+
+		if step%2000 != 0 {
+			continue
+		}
+		var keys = slices.Sort(mapKeys(ref), func(a, b int) bool { return a < b })
+		require.That(t, m.Len()).Eq(len(keys))
+		require.That(t, m.Keys()).Eq(keys)
+		for i, k := range keys {
+			var sk, sv, _ = m.Select(i)
+			require.That(t, sk).Eq(k)
+			require.That(t, sv).Eq(ref[k])
+			require.That(t, m.Rank(k)).Eq(i)
+		}
+		var lo, hi = r.IntN(3000), r.IntN(3000)
+		var expected = slices.Filter(keys, func(k int) bool { return k >= lo && k < hi })
+		require.That(t, collect(m.Range(lo, hi))).Eq(expected)
+
+		var below = slices.Filter(keys, func(k int) bool { return k <= lo })
+		var fk, _, ok = m.Floor(lo)
+		require.That(t, ok).Eq(len(below) > 0)
+		if ok {
+			require.That(t, fk).Eq(below[len(below)-1])
+		}
+	}
+}
+
+func mapKeys(m map[int]int) []int {
+	var r []int
+	for k := range m {
+		r = append(r, k)
+	}
+	return r
+}
@@ -0,0 +1,181 @@
+// Package tree provides traversal and transformation helpers for recursive
+// structures, where each node exposes its children through a `children`
+// function. All functions are iterative rather than recursive, and handle
+// arbitrarily deep trees without growing the call stack.
+package tree
+
+import "iter"
+
+// PreOrder returns an iterator over the nodes of the tree rooted at `root`, in
+// depth-first pre-order: each node before its children.
+func PreOrder[T any](root T, children func(n T) []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack = []T{root}
+		for len(stack) > 0 {
+			var n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n) {
+				return
+			}
+			var c = children(n)
+			for i := len(c) - 1; i >= 0; i-- {
+				stack = append(stack, c[i])
+			}
+		}
+	}
+}
+
+// PostOrder returns an iterator over the nodes of the tree rooted at `root`,
+// in depth-first post-order: each node after its children.
+func PostOrder[T any](root T, children func(n T) []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack = []frame[T, struct{}]{{node: root, children: children(root)}}
+		for len(stack) > 0 {
+			var f = &stack[len(stack)-1]
+			if f.next < len(f.children) {
+				var c = f.children[f.next]
+				f.next++
+				stack = append(stack, frame[T, struct{}]{node: c, children: children(c)})
+				continue
+			}
+			var n = f.node
+			stack = stack[:len(stack)-1]
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// LevelOrder returns an iterator over the nodes of the tree rooted at `root`,
+// in breadth-first order: level by level, starting with the root.
+func LevelOrder[T any](root T, children func(n T) []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var queue = []T{root}
+		for len(queue) > 0 {
+			var n = queue[0]
+			queue = queue[1:]
+			if !yield(n) {
+				return
+			}
+			queue = append(queue, children(n)...)
+		}
+	}
+}
+
+// Flatten returns the nodes of the tree rooted at `root` as a slice, in
+// pre-order.
+func Flatten[T any](root T, children func(n T) []T) []T {
+	var r []T
+	for n := range PreOrder(root, children) {
+		r = append(r, n)
+	}
+	return r
+}
+
+// Depth returns the number of nodes along the longest path from `root` down
+// to a leaf. A tree reduced to its root has a depth of 1.
+func Depth[T any](root T, children func(n T) []T) int {
+	type item struct {
+		node  T
+		depth int
+	}
+	var r = 0
+	var stack = []item{{root, 1}}
+	for len(stack) > 0 {
+		var it = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		r = max(r, it.depth)
+		for _, c := range children(it.node) {
+			stack = append(stack, item{c, it.depth + 1})
+		}
+	}
+	return r
+}
+
+// FindPath returns the path from `root` to the first node in pre-order for
+// which `pred` returns true, as the list of nodes along the path including
+// both ends. The second return value is false if no node matches.
+func FindPath[T any](root T, children func(n T) []T, pred func(n T) bool) ([]T, bool) {
+	if pred(root) {
+		return []T{root}, true
+	}
+	var stack = []frame[T, struct{}]{{node: root, children: children(root)}}
+	for len(stack) > 0 {
+		var f = &stack[len(stack)-1]
+		if f.next == len(f.children) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		var c = f.children[f.next]
+		f.next++
+		if pred(c) {
+			var path = make([]T, 0, len(stack)+1)
+			for _, f := range stack {
+				path = append(path, f.node)
+			}
+			return append(path, c), true
+		}
+		stack = append(stack, frame[T, struct{}]{node: c, children: children(c)})
+	}
+	return nil, false
+}
+
+// MapTree builds a new tree from the tree rooted at `root`, by invoking `f`
+// in post-order with each node and the already mapped children of that node,
+// and returns the mapped root.
+func MapTree[T any, U any](root T, children func(n T) []T, f func(n T, children []U) U) U {
+	return fold(root, children, func(T) bool { return true }, f)
+}
+
+// FilterPrune builds a new tree from the tree rooted at `root`, without the
+// nodes for which `keep` returns false, along with their entire subtree.
+// Kept nodes are rebuilt in post-order by invoking `rebuild` with the
+// original node and its rebuilt kept children. The second return value is
+// false if the root itself is pruned.
+func FilterPrune[T any](root T, children func(n T) []T, keep func(n T) bool,
+	rebuild func(n T, children []T) T) (T, bool) {
+
+	if !keep(root) {
+		var zero T
+		return zero, false
+	}
+	return fold(root, children, keep, rebuild), true
+}
+
+// frame is the state of a node being visited by an iterative depth-first
+// traversal: its children, the index of the next child to visit, and the
+// results accumulated for the children already visited.
+type frame[T any, U any] struct {
+	node     T
+	children []T
+	next     int
+	results  []U
+}
+
+// fold invokes `f` in post-order with each node of the tree accepted by
+// `enter` and the results of its accepted children, and returns the result for
+// `root`.
+func fold[T any, U any](root T, children func(n T) []T, enter func(n T) bool,
+	f func(n T, children []U) U) U {
+
+	var stack = []frame[T, U]{{node: root, children: children(root)}}
+	for {
+		var top = &stack[len(stack)-1]
+		if top.next < len(top.children) {
+			var c = top.children[top.next]
+			top.next++
+			if enter(c) {
+				stack = append(stack, frame[T, U]{node: c, children: children(c)})
+			}
+			continue
+		}
+		var u = f(top.node, top.results)
+		stack = stack[:len(stack)-1]
+		if len(stack) == 0 {
+			return u
+		}
+		var parent = &stack[len(stack)-1]
+		parent.results = append(parent.results, u)
+	}
+}
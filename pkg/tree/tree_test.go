@@ -0,0 +1,161 @@
+package tree_test
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/maargenton/go-generics/pkg/slices"
+	"github.com/maargenton/go-generics/pkg/tree"
+	"github.com/maargenton/go-testpredicate/pkg/require"
+)
+
+type node struct {
+	name     string
+	children []*node
+}
+
+func children(n *node) []*node {
+	return n.children
+}
+
+func names(v []*node) []string {
+	return slices.Map(v, func(n *node) string { return n.name })
+}
+
+func collect(seq iter.Seq[*node]) []string {
+	var r []string
+	for n := range seq {
+		r = append(r, n.name)
+	}
+	return r
+}
+
+// sample returns the tree `a(b(e f) c d(g))`, where each node is followed by
+// its children in parentheses.
+func sample() *node {
+	return &node{"a", []*node{
+		{"b", []*node{{name: "e"}, {name: "f"}}},
+		{name: "c"},
+		{"d", []*node{{name: "g"}}},
+	}}
+}
+
+// deep returns a degenerate tree of `n` nodes, each one the only child of the
+// previous one.
+func deep(n int) *node {
+	var root = &node{name: "0"}
+	var p = root
+	for i := 1; i < n; i++ {
+		var c = &node{name: fmt.Sprint(i)}
+		p.children = []*node{c}
+		p = c
+	}
+	return root
+}
+
+func TestPreOrder(t *testing.T) {
+	require.That(t, collect(tree.PreOrder(sample(), children))).
+		Eq([]string{"a", "b", "e", "f", "c", "d", "g"})
+}
+
+func TestPostOrder(t *testing.T) {
+	require.That(t, collect(tree.PostOrder(sample(), children))).
+		Eq([]string{"e", "f", "b", "c", "g", "d", "a"})
+}
+
+func TestLevelOrder(t *testing.T) {
+	require.That(t, collect(tree.LevelOrder(sample(), children))).
+		Eq([]string{"a", "b", "c", "d", "e", "f", "g"})
+}
+
+func TestTraversalsStopEarly(t *testing.T) {
+	var traversals = map[string]func(*node, func(*node) []*node) iter.Seq[*node]{
+		"PreOrder":   tree.PreOrder[*node],
+		"PostOrder":  tree.PostOrder[*node],
+		"LevelOrder": tree.LevelOrder[*node],
+	}
+	for name, traversal := range traversals {
+		t.Run(name, func(t *testing.T) {
+			var n = 0
+			for range traversal(sample(), children) {
+				n++
+				if n == 3 {
+					break
+				}
+			}
+			require.That(t, n).Eq(3)
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	require.That(t, names(tree.Flatten(sample(), children))).
+		Eq([]string{"a", "b", "e", "f", "c", "d", "g"})
+	require.That(t, names(tree.Flatten(&node{name: "x"}, children))).
+		Eq([]string{"x"})
+}
+
+func TestDepth(t *testing.T) {
+	require.That(t, tree.Depth(sample(), children)).Eq(3)
+	require.That(t, tree.Depth(&node{name: "x"}, children)).Eq(1)
+}
+
+func TestFindPath(t *testing.T) {
+	var is = func(name string) func(n *node) bool {
+		return func(n *node) bool { return n.name == name }
+	}
+	var p, ok = tree.FindPath(sample(), children, is("g"))
+	require.That(t, ok).IsTrue()
+	require.That(t, names(p)).Eq([]string{"a", "d", "g"})
+
+	p, _ = tree.FindPath(sample(), children, is("a"))
+	require.That(t, names(p)).Eq([]string{"a"})
+
+	_, ok = tree.FindPath(sample(), children, is("z"))
+	require.That(t, ok).IsFalse()
+}
+
+func TestMapTree(t *testing.T) {
+	var s = tree.MapTree(sample(), children, func(n *node, c []string) string {
+		if len(c) == 0 {
+			return n.name
+		}
+		return n.name + "(" + strings.Join(c, " ") + ")"
+	})
+	require.That(t, s).Eq("a(b(e f) c d(g))")
+}
+
+func TestFilterPrune(t *testing.T) {
+	var keep = func(n *node) bool { return n.name != "b" && n.name != "g" }
+	var rebuild = func(n *node, c []*node) *node {
+		return &node{name: strings.ToUpper(n.name), children: c}
+	}
+	var root = sample()
+	var r, ok = tree.FilterPrune(root, children, keep, rebuild)
+	require.That(t, ok).IsTrue()
+	require.That(t, names(tree.Flatten(r, children))).Eq([]string{"A", "C", "D"})
+	require.That(t, names(tree.Flatten(root, children))).
+		Eq([]string{"a", "b", "e", "f", "c", "d", "g"})
+
+	_, ok = tree.FilterPrune(root, children, func(*node) bool { return false }, rebuild)
+	require.That(t, ok).IsFalse()
+}
+
+func TestDeepTree(t *testing.T) {
+	const n = 200000
+	var root = deep(n)
+	require.That(t, tree.Depth(root, children)).Eq(n)
+	require.That(t, tree.Flatten(root, children)).Length().Eq(n)
+	require.That(t, collect(tree.PostOrder(root, children))[0]).Eq(fmt.Sprint(n - 1))
+	require.That(t, collect(tree.LevelOrder(root, children))).Length().Eq(n)
+
+	var p, _ = tree.FindPath(root, children, func(x *node) bool { return x.name == fmt.Sprint(n-1) })
+	require.That(t, p).Length().Eq(n)
+
+	var count = tree.MapTree(root, children, func(_ *node, c []int) int {
+		return 1 + slices.Reduce(c, 0, func(a, b int) int { return a + b })
+	})
+	require.That(t, count).Eq(n)
+}